@@ -106,6 +106,46 @@ func AddCommands(cmd *cobra.Command) {
 		cfg.P2P.AcceptQueue,
 		"number of connections that are fully setup before accepting new connections",
 	)
+	cmd.PersistentFlags().Float64Var(&cfg.P2P.AcceptRate,
+		"p2p-accept-rate",
+		cfg.P2P.AcceptRate,
+		"number of inbound connections per second accepted from a single ip address. 0 disables the limit",
+	)
+	cmd.PersistentFlags().IntVar(&cfg.P2P.AcceptBurst,
+		"p2p-accept-burst",
+		cfg.P2P.AcceptBurst,
+		"number of inbound connections accepted from a single ip address in a burst",
+	)
+	cmd.PersistentFlags().DurationVar(&cfg.P2P.BootstrapJitter,
+		"p2p-bootstrap-jitter",
+		cfg.P2P.BootstrapJitter,
+		"maximum random delay before dialing bootnodes and known peers on start. 0 disables the delay",
+	)
+	cmd.PersistentFlags().IntVar(&cfg.P2P.PeerOutboundQueue,
+		"p2p-peer-outbound-queue",
+		cfg.P2P.PeerOutboundQueue,
+		"number of gossip messages queued for each peer. messages to a peer with a full queue are dropped",
+	)
+	cmd.PersistentFlags().IntVar(&cfg.P2P.MaxKnownPeers,
+		"p2p-max-known-peers",
+		cfg.P2P.MaxKnownPeers,
+		"maximum number of peer addresses kept by discovery. stale addresses are evicted when it is reached",
+	)
+	cmd.PersistentFlags().DurationVar(&cfg.P2P.DialBackoff,
+		"p2p-dial-backoff",
+		cfg.P2P.DialBackoff,
+		"how long an address is not dialed again after a failed dial. 0 keeps the libp2p default",
+	)
+	cmd.PersistentFlags().DurationVar(&cfg.P2P.DNSCacheTTL,
+		"p2p-dns-cache-ttl",
+		cfg.P2P.DNSCacheTTL,
+		"how long resolved dns addresses of peers are reused. 0 resolves them on every dial",
+	)
+	cmd.PersistentFlags().DurationVar(&cfg.P2P.KeepAliveInterval,
+		"p2p-keep-alive-interval",
+		cfg.P2P.KeepAliveInterval,
+		"how long a connection may be idle before a keep-alive ping is sent on it. 0 keeps the yamux default",
+	)
 	cmd.PersistentFlags().IntVar(&cfg.P2P.LowPeers, "low-peers",
 		cfg.P2P.LowPeers, "low watermark for the number of connections")
 	cmd.PersistentFlags().IntVar(&cfg.P2P.HighPeers, "high-peers",
@@ -117,6 +157,10 @@ func AddCommands(cmd *cobra.Command) {
 		cfg.P2P.Bootnodes, "entrypoints into the network")
 	cmd.PersistentFlags().StringVar(&cfg.P2P.AdvertiseAddress, "advertise-address",
 		cfg.P2P.AdvertiseAddress, "libp2p address with identity (example: /dns4/bootnode.spacemesh.io/tcp/5003)")
+	cmd.PersistentFlags().StringSliceVar(&cfg.P2P.AllowCIDRs, "allow-cidrs",
+		cfg.P2P.AllowCIDRs, "if set only connections with addresses in these networks are accepted and dialed (example: 10.0.0.0/8)")
+	cmd.PersistentFlags().StringSliceVar(&cfg.P2P.DenyCIDRs, "deny-cidrs",
+		cfg.P2P.DenyCIDRs, "connections with addresses in these networks are never accepted nor dialed")
 
 	/** ======================== TIME Flags ========================== **/
 
@@ -39,6 +39,13 @@ func (fo *FixedRolacle) IsIdentityActiveOnConsensusView(ctx context.Context, edI
 	return true, nil
 }
 
+// ActiveCount returns the number of registered participants.
+func (fo *FixedRolacle) ActiveCount(context.Context, types.LayerID) (int, error) {
+	fo.mapRW.RLock()
+	defer fo.mapRW.RUnlock()
+	return len(fo.honest) + len(fo.faulty), nil
+}
+
 // Export creates a map with the eligible participants for id and committee size.
 func (fo *FixedRolacle) Export(id types.Hash32, committeeSize int) map[types.NodeID]struct{} {
 	fo.mapRW.RLock()
@@ -2,9 +2,11 @@
 package hare
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"fmt"
+	"sort"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -22,6 +24,14 @@ import (
 const (
 	// RoundsPerIteration is the number of rounds per iteration in the hare protocol.
 	RoundsPerIteration = 4
+
+	// participantsPollInterval is how often the oracle is queried while waiting for the minimum number of participants.
+	participantsPollInterval = 100 * time.Millisecond
+)
+
+var (
+	errNotEnoughParticipants = errors.New("not enough participants")
+	errAlreadyStarted        = errors.New("consensus process already started")
 )
 
 type role byte
@@ -40,14 +50,29 @@ const (
 // report is the termination report of the CP.
 type report struct {
 	id        types.LayerID // layer id
-	set       *Set          // agreed-upon set
-	completed bool          // whether the CP completed
+	set       *Set          // agreed-upon set, an empty set is a valid decision
+	completed bool          // whether the CP completed, false if it was aborted or ran out of iterations
 }
 
 func (proc *consensusProcess) report(completed bool) {
 	proc.comm.report <- report{id: proc.layer, set: proc.value, completed: completed}
 }
 
+// roundWait is the time a round waited until its threshold was met.
+type roundWait struct {
+	round    uint32
+	wait     time.Duration
+	timedOut bool // the threshold was not met before the end of the round
+}
+
+// AuditEntry records a threshold crossed by a consensus process.
+type AuditEntry struct {
+	Round   uint32         // the round counter (K) when the threshold was crossed
+	Type    MessageType    // the type of messages that crossed the threshold
+	Senders []types.NodeID // the identities whose messages were counted, ordered by id
+	SetID   types.Hash32   // the id of the set the messages agreed on
+}
+
 type wcReport struct {
 	id       types.LayerID
 	coinflip bool
@@ -153,6 +178,8 @@ type communication struct {
 	// if the consensus process terminates, output the result to report
 	report chan report
 	wc     chan wcReport
+	// if set, leader is called with the identity whose proposal was accepted in a proposal round
+	leader func(types.NodeID)
 }
 
 // consensusProcess is an entity (a single participant) in the Hare protocol.
@@ -180,15 +207,25 @@ type consensusProcess struct {
 	commitTracker    commitTrackerProvider
 	notifyTracker    *notifyTracker
 	cfg              config.Config
-	pending          map[types.NodeID]*Message // buffer for early messages that are pending process
-	mTracker         *msgsTracker              // tracks valid messages
-	eTracker         *EligibilityTracker       // tracks eligible identities by rounds
+	pending          *boundedBuffer      // buffer for early messages that are pending process, one per sender
+	mTracker         *msgsTracker        // tracks valid messages
+	eTracker         *EligibilityTracker // tracks eligible identities by rounds
 	eligibilityCount uint16
+	roundWaits       []roundWait  // wait times of the commit and notify rounds
+	auditLog         []AuditEntry // threshold-crossing events, at most cfg.AuditLogSize
+	decided          *Set         // the set reported upon termination, it never changes once set
 	clock            RoundClock
+	validValue       func(types.ProposalID) bool       // application-level validity of a value
+	provenance       map[types.ProposalID]types.NodeID // first sender of each value, nil unless cfg.ValueProvenance is set
 	once             sync.Once
+	started          bool
+	shutdown         chan struct{} // closed to terminate at the end of the current round
+	shutdownOnce     sync.Once
 }
 
 // newConsensusProcess creates a new consensus process instance.
+// validValue reports whether a value is valid for the application, values it rejects are never adopted.
+// A nil validValue accepts all values.
 func newConsensusProcess(
 	ctx context.Context,
 	cfg config.Config,
@@ -203,31 +240,42 @@ func newConsensusProcess(
 	p2p pubsub.Publisher,
 	comm communication,
 	ev roleValidator,
+	validValue func(types.ProposalID) bool,
 	clock RoundClock,
 	logger log.Log,
 ) *consensusProcess {
+	if validValue == nil {
+		validValue = func(types.ProposalID) bool { return true }
+	}
 	proc := &consensusProcess{
 		State: State{
 			round:          preRound,
 			committedRound: preRound,
-			value:          s.Clone(),
 		},
-		layer:     layer,
-		oracle:    oracle,
-		signer:    signing,
-		nid:       nid,
-		publisher: p2p,
-		cfg:       cfg,
-		comm:      comm,
-		pending:   make(map[types.NodeID]*Message, cfg.N),
-		Log:       logger,
-		mTracker:  newMsgsTracker(),
-		eTracker:  et,
-		clock:     clock,
+		layer:      layer,
+		oracle:     oracle,
+		signer:     signing,
+		nid:        nid,
+		publisher:  p2p,
+		cfg:        cfg,
+		comm:       comm,
+		pending:    newBoundedBuffer(inboxCapacity, true, bufferOptions(cfg)...),
+		Log:        logger,
+		mTracker:   newMsgsTracker(),
+		eTracker:   et,
+		clock:      clock,
+		validValue: validValue,
+		shutdown:   make(chan struct{}),
+	}
+	if cfg.ValueProvenance {
+		proc.provenance = make(map[types.ProposalID]types.NodeID)
 	}
+	proc.value = proc.filterValid(s)
 	proc.ctx, proc.cancel = context.WithCancel(ctx)
-	proc.preRoundTracker = newPreRoundTracker(logger.WithContext(proc.ctx).WithFields(proc.layer), comm.mchOut, proc.eTracker, cfg.N/2+1, cfg.N)
-	proc.validator = newSyntaxContextValidator(signing, edVerifier, cfg.N/2+1, proc.statusValidator(), stateQuerier, ev, proc.mTracker, proc.eTracker, logger)
+	proc.preRoundTracker = newPreRoundTracker(logger.WithContext(proc.ctx).WithFields(proc.layer), comm.mchOut, proc.eTracker, cfg.Threshold(config.ThresholdPreRound), cfg.N)
+	// aggregated messages are built by other nodes that may not share the threshold overrides,
+	// they are validated against the majority of the committee.
+	proc.validator = newSyntaxContextValidator(signing, edVerifier, cfg.N/2+1, cfg.SetSize, cfg.SetSizeTolerance, proc.statusValidator(), stateQuerier, ev, proc.mTracker, proc.eTracker, logger)
 
 	return proc
 }
@@ -240,16 +288,103 @@ func inferIteration(roundCounter uint32) uint32 {
 // Start the consensus process.
 // It starts the PreRound round and then iterates through the rounds until consensus is reached or the instance is canceled.
 // It is assumed that the inbox is set before the call to Start.
-// It returns an error if Start has been called more than once or the inbox is nil.
+// If a minimum number of participants is configured, the consensus process waits in the background until the
+// oracle reports enough active participants. If they are not reached within the configured timeout, it terminates
+// and reports that it didn't complete.
 func (proc *consensusProcess) Start() {
 	proc.once.Do(func() {
+		proc.mu.Lock()
+		proc.started = true
+		proc.mu.Unlock()
 		proc.eg.Go(func() error {
+			if err := proc.awaitParticipants(); err != nil {
+				if proc.ctx.Err() == nil {
+					proc.WithContext(proc.ctx).With().Warning("terminating: not starting", proc.layer, log.Err(err))
+					proc.report(notCompleted)
+				}
+				proc.terminate()
+				return nil
+			}
 			proc.eventLoop()
 			return nil
 		})
 	})
 }
 
+// awaitParticipants waits until the oracle reports at least cfg.MinParticipants active participants for the layer.
+func (proc *consensusProcess) awaitParticipants() error {
+	if proc.cfg.MinParticipants <= 0 {
+		return nil
+	}
+	logger := proc.WithContext(proc.ctx).WithFields(proc.layer)
+	deadline := time.NewTimer(proc.cfg.StartTimeout)
+	defer deadline.Stop()
+	ticker := time.NewTicker(participantsPollInterval)
+	defer ticker.Stop()
+	for {
+		count, err := proc.oracle.ActiveCount(proc.ctx, proc.layer)
+		if err != nil {
+			logger.With().Debug("failed to get number of active participants", log.Err(err))
+		} else if count >= proc.cfg.MinParticipants {
+			return nil
+		}
+		select {
+		case <-ticker.C:
+		case <-deadline.C:
+			return fmt.Errorf("%w: %d active, %d required", errNotEnoughParticipants, count, proc.cfg.MinParticipants)
+		case <-proc.ctx.Done():
+			return proc.ctx.Err()
+		}
+	}
+}
+
+// SetInitialValues replaces the initial set of values of the consensus process.
+// Values rejected by the validity predicate are dropped.
+// It returns an error if the consensus process was already started.
+func (proc *consensusProcess) SetInitialValues(s *Set) error {
+	proc.mu.Lock()
+	defer proc.mu.Unlock()
+	if proc.started {
+		return errAlreadyStarted
+	}
+	proc.value = proc.filterValid(s)
+	return nil
+}
+
+// filterValid returns a copy of s without the values rejected by the validity predicate.
+func (proc *consensusProcess) filterValid(s *Set) *Set {
+	valid := s.Clone()
+	for _, v := range s.ToSlice() {
+		if !proc.validValue(v) {
+			valid.Remove(v)
+		}
+	}
+	return valid
+}
+
+// hasInvalidValue returns true if any value of the message is rejected by the validity predicate.
+// the values of status and proposal messages are signed and referenced by SVPs, so a message with
+// an invalid value is ignored as a whole rather than filtered.
+func (proc *consensusProcess) hasInvalidValue(ctx context.Context, msg *Message) bool {
+	for _, v := range msg.Values {
+		if !proc.validValue(v) {
+			proc.WithContext(ctx).With().Warning("ignoring message with invalid value",
+				proc.layer,
+				log.String("msg_type", msg.Type.String()),
+				log.Stringer("smesher", msg.SmesherID),
+				log.Stringer("value", v))
+			return true
+		}
+	}
+	return false
+}
+
+// Participants returns the identities that sent messages to the consensus process, with the rounds
+// they participated in.
+func (proc *consensusProcess) Participants() []Participant {
+	return proc.eTracker.Participants(proc.getRound())
+}
+
 // ID returns the instance id.
 func (proc *consensusProcess) ID() types.LayerID {
 	return proc.layer
@@ -263,6 +398,38 @@ func (proc *consensusProcess) Stop() {
 	_ = proc.eg.Wait()
 }
 
+// Shutdown lets the current round run until it ends and then terminates the consensus process,
+// so that messages are processed and the round's outcome still counts towards the quorum.
+// If ctx is done before the end of the round the consensus process is terminated right away and ctx.Err() is returned.
+// Unlike termination on a decision, a shut down consensus process doesn't report an output.
+func (proc *consensusProcess) Shutdown(ctx context.Context) error {
+	// the context of the process is canceled even if it was never started
+	defer proc.terminate()
+	proc.shutdownOnce.Do(func() { close(proc.shutdown) })
+	done := make(chan struct{})
+	go func() {
+		proc.Stop()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		proc.terminate()
+		<-done
+		return ctx.Err()
+	}
+}
+
+func (proc *consensusProcess) shuttingDown() bool {
+	select {
+	case <-proc.shutdown:
+		return true
+	default:
+		return false
+	}
+}
+
 func (proc *consensusProcess) terminating() bool {
 	select {
 	case <-proc.ctx.Done():
@@ -281,6 +448,13 @@ func (proc *consensusProcess) eventLoop() {
 		log.Int("set_size", proc.value.Size()),
 	)
 
+	var deadline <-chan time.Time
+	if proc.cfg.Deadline > 0 {
+		timer := time.NewTimer(proc.cfg.Deadline)
+		defer timer.Stop()
+		deadline = timer.C
+	}
+
 	// check participation and send message
 	proc.eg.Go(func() error {
 		// check participation
@@ -317,26 +491,21 @@ PreRound:
 			}
 		case <-endOfRound:
 			break PreRound
+		case <-deadline:
+			proc.onDeadline(ctx)
+			return
 		case <-proc.ctx.Done():
 			logger.With().Info("terminating: received signal during preround",
 				log.Uint32("current_round", proc.getRound()))
 			return
 		}
 	}
-	logger.With().Debug("preround ended, filtering preliminary set",
-		log.Int("set_size", proc.value.Size()))
-	proc.preRoundTracker.FilterSet(proc.value)
-	if proc.value.Size() == 0 {
-		logger.Event().Warning("preround ended with empty set")
-	} else {
-		logger.With().Info("preround ended",
-			log.Int("set_size", proc.value.Size()))
+	if proc.shuttingDown() {
+		logger.Info("terminating: shut down at the end of preround")
+		proc.terminate()
+		return
 	}
-	proc.reportWeakCoin()
-	proc.advanceToNextRound(ctx) // K was initialized to -1, K should be 0
-
-	// start first iteration
-	proc.onRoundBegin(ctx)
+	proc.onPreRoundEnd(ctx)
 	endOfRound = proc.clock.AwaitEndOfRound(proc.getRound())
 
 	for {
@@ -353,24 +522,21 @@ PreRound:
 				proc.Log.Fatal("unexpected message type")
 			}
 		case <-endOfRound: // next round event
-			proc.onRoundEnd(ctx)
-			if proc.terminating() {
+			if proc.shuttingDown() {
+				proc.onRoundEnd(ctx)
+				logger.With().Info("terminating: shut down at the end of the round",
+					log.Uint32("current_round", proc.getRound()))
+				proc.terminate()
 				return
 			}
-			proc.advanceToNextRound(ctx)
-
-			// exit if we reached the limit on number of iterations
-			round := proc.getRound()
-			if round >= uint32(proc.cfg.LimitIterations)*RoundsPerIteration {
-				logger.With().Warning("terminating: reached iterations limit",
-					log.Int("limit", proc.cfg.LimitIterations),
-					log.Uint32("current_round", round))
-				proc.report(notCompleted)
-				proc.terminate()
+			if !proc.nextRound(ctx) {
 				return
 			}
-			proc.onRoundBegin(ctx)
-			endOfRound = proc.clock.AwaitEndOfRound(round)
+			endOfRound = proc.clock.AwaitEndOfRound(proc.getRound())
+
+		case <-deadline: // deadline event
+			proc.onDeadline(ctx)
+			return
 
 		case <-proc.ctx.Done(): // close event
 			logger.With().Debug("terminating: received signal",
@@ -380,6 +546,62 @@ PreRound:
 	}
 }
 
+// filters the preliminary set by the preround messages and starts the first iteration.
+func (proc *consensusProcess) onPreRoundEnd(ctx context.Context) {
+	logger := proc.WithContext(ctx).WithFields(proc.layer)
+	logger.With().Debug("preround ended, filtering preliminary set",
+		log.Int("set_size", proc.value.Size()))
+	proc.preRoundTracker.FilterSet(proc.value)
+	if proc.value.Size() == 0 {
+		logger.Event().Warning("preround ended with empty set")
+	} else {
+		logger.With().Info("preround ended",
+			log.Int("set_size", proc.value.Size()))
+	}
+	proc.reportWeakCoin()
+	proc.advanceToNextRound(ctx) // K was initialized to -1, K should be 0
+
+	// start first iteration
+	proc.onRoundBegin(ctx)
+}
+
+// ends the current round and begins the next one.
+// returns false if the consensus process terminated or reached the limit on the number of iterations.
+func (proc *consensusProcess) nextRound(ctx context.Context) bool {
+	proc.onRoundEnd(ctx)
+	if proc.terminating() {
+		return false
+	}
+	proc.advanceToNextRound(ctx)
+
+	// exit if we reached the limit on number of iterations
+	round := proc.getRound()
+	if round >= uint32(proc.cfg.LimitIterations)*RoundsPerIteration {
+		proc.WithContext(ctx).With().Warning("terminating: reached iterations limit",
+			proc.layer,
+			log.Int("limit", proc.cfg.LimitIterations),
+			log.Uint32("current_round", round))
+		proc.report(notCompleted)
+		proc.terminate()
+		return false
+	}
+	proc.onRoundBegin(ctx)
+	return true
+}
+
+// aborts the consensus process that did not terminate by the deadline.
+func (proc *consensusProcess) onDeadline(ctx context.Context) {
+	if proc.terminating() {
+		return
+	}
+	proc.WithContext(ctx).With().Warning("terminating: reached deadline",
+		proc.layer,
+		log.Duration("deadline", proc.cfg.Deadline),
+		log.Uint32("current_round", proc.getRound()))
+	proc.report(notCompleted)
+	proc.terminate()
+}
+
 // handles eligibility proof from hare gossip handler and malfeasance proof gossip handler.
 func (proc *consensusProcess) onMalfeasance(msg *types.HareEligibilityGossip) {
 	proc.eTracker.Track(msg.NodeID, msg.Round, msg.Eligibility.Count, false)
@@ -404,14 +626,17 @@ func (proc *consensusProcess) onEarlyMessage(ctx context.Context, m *Message) {
 		return
 	}
 
-	if _, exist := proc.pending[m.SmesherID]; exist { // ignore, already received
+	switch err := proc.pending.add(m.SmesherID, m); {
+	case errors.Is(err, errDuplicateSender): // ignore, already received
 		logger.With().Warning("already received message from sender",
 			log.Stringer("smesher", m.SmesherID),
 		)
-		return
+	case err != nil:
+		logger.With().Warning("too many pending messages, ignoring message",
+			log.Int("inbox_capacity", inboxCapacity),
+			log.Stringer("smesher", m.SmesherID),
+		)
 	}
-
-	proc.pending[m.SmesherID] = m
 }
 
 // the very first step of handling a message.
@@ -476,6 +701,9 @@ func (proc *consensusProcess) processMsg(ctx context.Context, m *Message) {
 	// Report the latency since the beginning of the round
 	latency := time.Since(proc.clock.RoundEnd(m.Round - 1))
 	metrics.ReportMessageLatency(pubsub.HareProtocol, m.Type.String(), latency)
+	if m.Type == pre || m.Type == proposal || m.Type == notify {
+		proc.recordProvenance(m)
+	}
 	switch m.Type {
 	case pre:
 		proc.processPreRoundMsg(ctx, m)
@@ -496,6 +724,35 @@ func (proc *consensusProcess) processMsg(ctx context.Context, m *Message) {
 	}
 }
 
+// records the sender of the message as the provenance of the valid values seen for the first time.
+func (proc *consensusProcess) recordProvenance(m *Message) {
+	if proc.provenance == nil {
+		return
+	}
+	proc.mu.Lock()
+	defer proc.mu.Unlock()
+	for _, v := range m.Values {
+		if _, ok := proc.provenance[v]; !ok && proc.validValue(v) {
+			proc.provenance[v] = m.SmesherID
+		}
+	}
+}
+
+// Provenance returns the identity whose preround, proposal or notify message first carried each value.
+// The values of the working set are a subset of its keys. It returns nil unless cfg.ValueProvenance is set.
+func (proc *consensusProcess) Provenance() map[types.ProposalID]types.NodeID {
+	if proc.provenance == nil {
+		return nil
+	}
+	proc.mu.RLock()
+	defer proc.mu.RUnlock()
+	rst := make(map[types.ProposalID]types.NodeID, len(proc.provenance))
+	for v, id := range proc.provenance {
+		rst[v] = id
+	}
+	return rst
+}
+
 // sends a message to the network.
 // Returns true if the message is assumed to be sent, false otherwise.
 func (proc *consensusProcess) sendMessage(ctx context.Context, msg *Message) bool {
@@ -546,8 +803,14 @@ func (proc *consensusProcess) onRoundEnd(ctx context.Context) {
 			log.Int("set_size", proc.value.Size()),
 			log.String("proposed_set", sStr),
 			log.Bool("is_conflicting", proc.proposalTracker.IsConflicting()))
+		if id, ok := proc.proposalTracker.Leader(); ok && proc.comm.leader != nil {
+			proc.comm.leader(id)
+		}
 	case commitRound:
+		proc.recordRoundWait(commit, true)
 		logger.With().Debug("commit round ended", log.Int("set_size", proc.value.Size()))
+	case notifyRound:
+		proc.recordRoundWait(notify, true)
 	}
 }
 
@@ -568,7 +831,7 @@ func (proc *consensusProcess) beginStatusRound(ctx context.Context) {
 		proc.getRound(),
 		proc.comm.mchOut,
 		proc.eTracker,
-		proc.cfg.N/2+1,
+		proc.cfg.Threshold(config.ThresholdStatus),
 		proc.cfg.N)
 
 	// check participation
@@ -589,7 +852,8 @@ func (proc *consensusProcess) beginProposalRound(ctx context.Context) {
 	proc.proposalTracker = newProposalTracker(
 		proc.Log.WithContext(proc.ctx).WithFields(proc.layer),
 		proc.comm.mchOut,
-		proc.eTracker)
+		proc.eTracker,
+		proc.cfg.ProposalSelection)
 
 	// done with building proposal, reset statuses tracking
 	defer func() { proc.statusesTracker = nil }()
@@ -619,7 +883,7 @@ func (proc *consensusProcess) beginCommitRound(ctx context.Context) {
 		proc.getRound(),
 		proc.comm.mchOut,
 		proc.eTracker,
-		proc.cfg.N/2+1,
+		proc.cfg.Threshold(config.ThresholdCommit),
 		proc.cfg.N,
 		proposedSet)
 
@@ -665,7 +929,7 @@ func (proc *consensusProcess) beginNotifyRound(ctx context.Context) {
 
 	if !proc.commitTracker.HasEnoughCommits() {
 		logger.With().Warning("begin notify round: not enough commits",
-			log.Int("expected", proc.cfg.N/2+1),
+			log.Int("expected", proc.cfg.Threshold(config.ThresholdCommit)),
 			log.Object("actual", proc.commitTracker.CommitCount()))
 		return
 	}
@@ -706,7 +970,7 @@ func (proc *consensusProcess) beginNotifyRound(ctx context.Context) {
 }
 
 // passes all pending messages to the inbox of the process so they will be handled.
-func (proc *consensusProcess) handlePending(pending map[types.NodeID]*Message) {
+func (proc *consensusProcess) handlePending(pending []any) {
 	for _, m := range pending {
 		select {
 		case <-proc.ctx.Done():
@@ -733,13 +997,12 @@ func (proc *consensusProcess) onRoundBegin(ctx context.Context) {
 		proc.Fatal(fmt.Sprintf("current round out of bounds. Expected: 0-3, Found: %v", proc.currentRound()))
 	}
 
-	if len(proc.pending) == 0 { // no pending messages
+	if proc.pending.size() == 0 { // no pending messages
 		return
 	}
 
 	// handle pending messages
-	pendingProcess := proc.pending
-	proc.pending = make(map[types.NodeID]*Message, proc.cfg.N)
+	pendingProcess := proc.pending.drain()
 	proc.eg.Go(func() error {
 		proc.handlePending(pendingProcess)
 		return nil
@@ -764,11 +1027,17 @@ func (proc *consensusProcess) processPreRoundMsg(ctx context.Context, msg *Messa
 }
 
 func (proc *consensusProcess) processStatusMsg(ctx context.Context, msg *Message) {
+	if proc.hasInvalidValue(ctx, msg) {
+		return
+	}
 	// record status
 	proc.statusesTracker.RecordStatus(ctx, msg)
 }
 
 func (proc *consensusProcess) processProposalMsg(ctx context.Context, msg *Message) {
+	if proc.hasInvalidValue(ctx, msg) {
+		return
+	}
 	currRnd := proc.currentRound()
 
 	if currRnd == proposalRound { // regular proposal
@@ -785,6 +1054,10 @@ func (proc *consensusProcess) processProposalMsg(ctx context.Context, msg *Messa
 func (proc *consensusProcess) processCommitMsg(ctx context.Context, msg *Message) {
 	proc.mTracker.Track(msg) // a commit msg passed for processing is assumed to be valid
 	proc.commitTracker.OnCommit(ctx, msg)
+	if proc.currentRound() == commitRound && proc.commitTracker.HasEnoughCommits() {
+		proc.recordRoundWait(commit, false)
+		proc.auditCommits(ctx)
+	}
 }
 
 func (proc *consensusProcess) processNotifyMsg(ctx context.Context, msg *Message) {
@@ -802,7 +1075,7 @@ func (proc *consensusProcess) processNotifyMsg(ctx context.Context, msg *Message
 		return
 	}
 
-	if proc.currentRound() == notifyRound { // not necessary to update otherwise
+	if proc.currentRound() == notifyRound && proc.decided == nil { // not necessary to update otherwise
 		// we assume that this expression was checked before
 		if msg.Cert.AggMsgs.Messages[0].Round >= proc.committedRound { // update state iff K >= Ki
 			proc.value = s
@@ -811,7 +1084,7 @@ func (proc *consensusProcess) processNotifyMsg(ctx context.Context, msg *Message
 		}
 	}
 
-	threshold := proc.cfg.N/2 + 1
+	threshold := proc.cfg.Threshold(config.ThresholdNotify)
 	notifyCount := proc.notifyTracker.NotificationsCount(s)
 	if notifyCount == nil {
 		proc.WithContext(ctx).Fatal("unexpected count")
@@ -826,19 +1099,47 @@ func (proc *consensusProcess) processNotifyMsg(ctx context.Context, msg *Message
 		return
 	}
 
+	if proc.decided != nil {
+		// the instance already terminated, a different decision would violate safety
+		if !proc.decided.Equals(s) {
+			proc.WithContext(ctx).With().Error("refusing to decide a different set after termination",
+				proc.layer,
+				log.Stringer("decided_set", proc.decided),
+				log.Stringer("conflicting_set", s),
+				log.Object("notify_count", notifyCount))
+		}
+		return
+	}
+
 	// enough notifications, should terminate
+	if proc.currentRound() == notifyRound {
+		proc.recordRoundWait(notify, false)
+	}
 	proc.value = s // update to the agreed set
+	proc.audit(ctx, AuditEntry{Round: proc.getRound(), Type: notify, Senders: proc.notifyTracker.Notifiers(s), SetID: s.ID()})
 	proc.WithContext(ctx).Event().Info("consensus process terminated",
 		log.String("current_set", proc.value.String()),
 		log.Uint32("current_round", proc.getRound()),
 		proc.layer,
 		log.Object("notify_count", notifyCount),
 		log.Int("set_size", proc.value.Size()))
+	proc.decided = proc.value.Clone()
 	proc.report(completed)
 	numIterations.Observe(float64(proc.getRound()))
 	proc.terminate()
 }
 
+// Round returns the round counter (K) and the type of messages exchanged in the current round.
+// It is safe for concurrent use.
+func (proc *consensusProcess) Round() (uint32, MessageType) {
+	k := proc.getRound()
+	if k == preRound {
+		return k, pre
+	}
+	// the round types are numbered as the rounds of an iteration
+	return k, MessageType(k % RoundsPerIteration)
+}
+
 func (proc *consensusProcess) currentRound() uint32 {
 	return proc.getRound() % RoundsPerIteration
 }
@@ -960,6 +1261,85 @@ func (proc *consensusProcess) setEligibilityCount(count uint16) {
 	proc.eligibilityCount = count
 }
 
+// records the time since the beginning of the current round, once per round.
+// if timedOut is set the threshold of the round was not met and the whole round is recorded.
+func (proc *consensusProcess) recordRoundWait(mType MessageType, timedOut bool) {
+	round := proc.getRound()
+	proc.mu.Lock()
+	defer proc.mu.Unlock()
+	if n := len(proc.roundWaits); n > 0 && proc.roundWaits[n-1].round == round {
+		return
+	}
+	wait := time.Since(proc.clock.RoundEnd(round - 1))
+	proc.roundWaits = append(proc.roundWaits, roundWait{round: round, wait: wait, timedOut: timedOut})
+	outcome := success
+	if timedOut {
+		outcome = timeout
+	}
+	roundWaitTime.WithLabelValues(mType.String(), outcome).Observe(wait.Seconds())
+}
+
+// records the commits of the certificate once the commit threshold is crossed, once per round.
+func (proc *consensusProcess) auditCommits(ctx context.Context) {
+	if proc.cfg.AuditLogSize == 0 {
+		return
+	}
+	round := proc.getRound()
+	proc.mu.RLock()
+	n := len(proc.auditLog)
+	recorded := n > 0 && proc.auditLog[n-1].Round == round && proc.auditLog[n-1].Type == commit
+	proc.mu.RUnlock()
+	if recorded {
+		return
+	}
+	cert := proc.commitTracker.BuildCertificate()
+	if cert == nil {
+		return
+	}
+	senders := make([]types.NodeID, 0, len(cert.AggMsgs.Messages))
+	for _, m := range cert.AggMsgs.Messages {
+		senders = append(senders, m.SmesherID)
+	}
+	sort.Slice(senders, func(i, j int) bool {
+		return bytes.Compare(senders[i].Bytes(), senders[j].Bytes()) < 0
+	})
+	proc.audit(ctx, AuditEntry{Round: round, Type: commit, Senders: senders, SetID: NewSet(cert.Values).ID()})
+}
+
+// appends the entry to the audit log and drops the oldest entry if the log is full.
+func (proc *consensusProcess) audit(ctx context.Context, entry AuditEntry) {
+	if proc.cfg.AuditLogSize == 0 {
+		return
+	}
+	proc.WithContext(ctx).With().Info("threshold crossed",
+		proc.layer,
+		log.Uint32("round", entry.Round),
+		log.Stringer("msg_type", entry.Type),
+		log.Int("senders", len(entry.Senders)),
+		log.Stringer("set_id", entry.SetID),
+	)
+	proc.mu.Lock()
+	defer proc.mu.Unlock()
+	if len(proc.auditLog) == proc.cfg.AuditLogSize {
+		proc.auditLog = proc.auditLog[1:]
+	}
+	proc.auditLog = append(proc.auditLog, entry)
+}
+
+// AuditLog returns the most recent threshold-crossing events, oldest first.
+// It returns nil unless cfg.AuditLogSize is set. It is safe for concurrent use.
+func (proc *consensusProcess) AuditLog() []AuditEntry {
+	proc.mu.RLock()
+	defer proc.mu.RUnlock()
+	return append([]AuditEntry(nil), proc.auditLog...)
+}
+
+func (proc *consensusProcess) getRoundWaits() []roundWait {
+	proc.mu.RLock()
+	defer proc.mu.RUnlock()
+	return append([]roundWait(nil), proc.roundWaits...)
+}
+
 func (proc *consensusProcess) getRound() uint32 {
 	return atomic.LoadUint32(&proc.round)
 }
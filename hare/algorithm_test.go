@@ -1,8 +1,10 @@
 package hare
 
 import (
+	"bytes"
 	"context"
 	"errors"
+	"sort"
 	"sync"
 	"sync/atomic"
 	"testing"
@@ -11,6 +13,9 @@ import (
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
 
 	"github.com/spacemeshos/go-spacemesh/common/types"
 	"github.com/spacemeshos/go-spacemesh/eligibility"
@@ -82,6 +87,36 @@ func (m *mockP2p) setErr(err error) {
 	m.err = err
 }
 
+// memPublisher keeps the messages published by a consensus process in memory.
+type memPublisher struct {
+	mu   sync.Mutex
+	msgs []*Message
+}
+
+func (mp *memPublisher) Publish(_ context.Context, _ string, buf []byte) error {
+	msg, err := MessageFromBuffer(buf)
+	if err != nil {
+		return err
+	}
+	mp.mu.Lock()
+	defer mp.mu.Unlock()
+	mp.msgs = append(mp.msgs, msg)
+	return nil
+}
+
+// published returns the published messages of the type.
+func (mp *memPublisher) published(mType MessageType) []*Message {
+	mp.mu.Lock()
+	defer mp.mu.Unlock()
+	var msgs []*Message
+	for _, msg := range mp.msgs {
+		if msg.Type == mType {
+			msgs = append(msgs, msg)
+		}
+	}
+	return msgs
+}
+
 type mockProposalTracker struct {
 	isConflicting       bool
 	proposedSet         *Set
@@ -109,6 +144,10 @@ func (mpt *mockProposalTracker) ProposedSet() *Set {
 	return mpt.proposedSet
 }
 
+func (mpt *mockProposalTracker) Leader() (types.NodeID, bool) {
+	return types.EmptyNodeID, false
+}
+
 type mockCommitTracker struct {
 	countOnCommit         int
 	countHasEnoughCommits int
@@ -225,6 +264,23 @@ func TestConsensusProcess_TerminationLimit(t *testing.T) {
 	}, 2*time.Second, 200*time.Millisecond)
 }
 
+func TestConsensusProcess_Deadline(t *testing.T) {
+	c := config.Config{N: 10, RoundDuration: 50 * time.Millisecond, ExpectedLeaders: 5, LimitIterations: 1000, LimitConcurrent: 1, Hdist: 20, Deadline: 500 * time.Millisecond}
+	p := generateConsensusProcessWithConfig(t, c, make(chan any, 10))
+	p.publisher = &mockP2p{}
+	p.Start()
+
+	select {
+	case out := <-p.comm.report:
+		require.False(t, out.completed)
+		require.Equal(t, p.layer, out.id)
+	case <-time.After(5 * time.Second):
+		require.Fail(t, "timed out waiting for the deadline")
+	}
+	require.True(t, p.terminating())
+	p.Stop()
+}
+
 func TestConsensusProcess_PassiveParticipant(t *testing.T) {
 	c := config.Config{N: 10, RoundDuration: 200 * time.Millisecond, ExpectedLeaders: 5, LimitIterations: 1, LimitConcurrent: 1, Hdist: 20}
 	p := generateConsensusProcessWithConfig(t, c, make(chan any, 10))
@@ -279,6 +335,70 @@ func TestConsensusProcess_StartAndStop(t *testing.T) {
 	}, 500*time.Millisecond, 100*time.Millisecond)
 }
 
+func TestConsensusProcess_StartMinParticipants(t *testing.T) {
+	c := config.Config{N: 10, RoundDuration: 50 * time.Millisecond, ExpectedLeaders: 5, LimitIterations: 1, LimitConcurrent: 1000, Hdist: 20, MinParticipants: 3, StartTimeout: time.Second}
+	proc := generateConsensusProcessWithConfig(t, c, make(chan any, 10))
+	proc.publisher = &mockP2p{}
+
+	mo := mocks.NewMockRolacle(gomock.NewController(t))
+	gomock.InOrder(
+		mo.EXPECT().ActiveCount(gomock.Any(), proc.layer).Return(0, errors.New("no active set")),
+		mo.EXPECT().ActiveCount(gomock.Any(), proc.layer).Return(2, nil),
+		mo.EXPECT().ActiveCount(gomock.Any(), proc.layer).Return(3, nil),
+	)
+	mo.EXPECT().IsIdentityActiveOnConsensusView(gomock.Any(), gomock.Any(), proc.layer).Return(true, nil).AnyTimes()
+	mo.EXPECT().Proof(gomock.Any(), gomock.Any(), gomock.Any()).Return(types.EmptyVrfSignature, nil).AnyTimes()
+	mo.EXPECT().CalcEligibility(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), proc.nid, gomock.Any()).Return(uint16(1), nil).AnyTimes()
+	proc.oracle = mo
+
+	proc.Start()
+	require.Eventually(t, func() bool {
+		return proc.getRound() != preRound
+	}, time.Second, 10*time.Millisecond)
+	proc.terminate()
+	proc.Stop()
+}
+
+func TestConsensusProcess_StartNotEnoughParticipants(t *testing.T) {
+	c := config.Config{N: 10, RoundDuration: 50 * time.Millisecond, ExpectedLeaders: 5, LimitIterations: 1, LimitConcurrent: 1000, Hdist: 20, MinParticipants: 3, StartTimeout: 300 * time.Millisecond}
+	proc := generateConsensusProcessWithConfig(t, c, make(chan any, 10))
+	proc.publisher = &mockP2p{}
+
+	mo := mocks.NewMockRolacle(gomock.NewController(t))
+	mo.EXPECT().ActiveCount(gomock.Any(), proc.layer).Return(2, nil).MinTimes(1)
+	proc.oracle = mo
+
+	// start doesn't wait for the participants
+	proc.Start()
+	select {
+	case out := <-proc.comm.report:
+		require.Equal(t, proc.layer, out.id)
+		require.False(t, out.completed)
+	case <-time.After(time.Second):
+		require.Fail(t, "no report")
+	}
+	proc.Stop()
+	require.Equal(t, preRound, proc.getRound())
+	require.ErrorIs(t, proc.ctx.Err(), context.Canceled)
+}
+
+func TestConsensusProcess_SetInitialValues(t *testing.T) {
+	c := config.Config{N: 10, RoundDuration: 50 * time.Millisecond, ExpectedLeaders: 5, LimitIterations: 1, LimitConcurrent: 1000, Hdist: 20}
+	proc := generateConsensusProcessWithConfig(t, c, make(chan any, 10))
+	proc.publisher = &mockP2p{}
+
+	s := NewSetFromValues(types.ProposalID{1}, types.ProposalID{2})
+	require.NoError(t, proc.SetInitialValues(s))
+	require.True(t, s.Equals(proc.value))
+	s.Add(types.ProposalID{3})
+	require.False(t, s.Equals(proc.value))
+
+	proc.Start()
+	require.ErrorIs(t, proc.SetInitialValues(NewSetFromValues(types.ProposalID{4})), errAlreadyStarted)
+	proc.terminate()
+	proc.Stop()
+}
+
 func TestConsensusProcess_handleMessage(t *testing.T) {
 	ctrl := gomock.NewController(t)
 
@@ -309,7 +429,7 @@ func TestConsensusProcess_handleMessage(t *testing.T) {
 	mValidator.contextValid = nil
 	proc.handleMessage(context.Background(), msg)
 	r.True(proc.preRoundTracker.coinflip)
-	r.Equal(0, len(proc.pending))
+	r.Equal(0, proc.pending.size())
 	r.Equal(3, mValidator.countContext)
 	r.Equal(3, mValidator.countSyntax)
 	mValidator.contextValid = errors.New("not valid")
@@ -317,11 +437,71 @@ func TestConsensusProcess_handleMessage(t *testing.T) {
 	r.True(proc.preRoundTracker.coinflip)
 	r.Equal(4, mValidator.countContext)
 	r.Equal(3, mValidator.countSyntax)
-	r.Equal(0, len(proc.pending))
+	r.Equal(0, proc.pending.size())
 	mValidator.contextValid = errEarlyMsg
 	proc.handleMessage(context.Background(), msg)
 	r.True(proc.preRoundTracker.coinflip)
-	r.Equal(1, len(proc.pending))
+	r.Equal(1, proc.pending.size())
+}
+
+// the round logic runs without a network by feeding messages directly to the process.
+func TestConsensusProcess_Isolated(t *testing.T) {
+	c := config.Config{N: 4, RoundDuration: 2 * time.Second, ExpectedLeaders: 4, LimitIterations: 1000, LimitConcurrent: 1000, Hdist: 20}
+	proc := generateConsensusProcessWithConfig(t, c, make(chan any, 10))
+	proc.validator = &mockMessageValidator{syntaxValid: true}
+	require.NoError(t, proc.SetInitialValues(NewSetFromValues(types.ProposalID{1}, types.ProposalID{2}, types.ProposalID{3})))
+
+	// values 1 and 2 are supported by 3 nodes, value 3 by 2 nodes
+	for i, values := range []*Set{
+		NewSetFromValues(types.ProposalID{1}, types.ProposalID{2}, types.ProposalID{3}),
+		NewSetFromValues(types.ProposalID{1}, types.ProposalID{2}, types.ProposalID{3}),
+		NewSetFromValues(types.ProposalID{1}, types.ProposalID{2}),
+	} {
+		signer, err := signing.NewEdSigner()
+		require.NoError(t, err)
+		proc.handleMessage(context.Background(), BuildPreRoundMsg(signer, values, types.VrfSignature{byte(i)}))
+	}
+	proc.onPreRoundEnd(context.Background())
+
+	require.Equal(t, statusRound, proc.getRound())
+	statuses := proc.publisher.(*memPublisher).published(status)
+	require.Len(t, statuses, 1)
+	require.Equal(t, []types.ProposalID{{1}, {2}}, statuses[0].Values)
+}
+
+func TestConsensusProcess_Participants(t *testing.T) {
+	proc := generateConsensusProcess(t)
+	proc.validator = &mockMessageValidator{syntaxValid: true}
+	require.Empty(t, proc.Participants())
+
+	s := NewSetFromValues(types.ProposalID{1})
+	signer1, err := signing.NewEdSigner()
+	require.NoError(t, err)
+	signer2, err := signing.NewEdSigner()
+	require.NoError(t, err)
+	byID := func() map[types.NodeID]Participant {
+		participants := map[types.NodeID]Participant{}
+		for _, p := range proc.Participants() {
+			participants[p.ID] = p
+		}
+		return participants
+	}
+
+	proc.handleMessage(context.Background(), BuildPreRoundMsg(signer1, s, types.EmptyVrfSignature))
+	require.Equal(t, map[types.NodeID]Participant{
+		signer1.NodeID(): {ID: signer1.NodeID(), Rounds: []uint32{preRound}, Honest: true, Current: true},
+	}, byID())
+
+	proc.handleMessage(context.Background(), BuildPreRoundMsg(signer2, s, types.EmptyVrfSignature))
+	require.Len(t, proc.Participants(), 2)
+
+	proc.advanceToNextRound(context.Background())
+	proc.beginStatusRound(context.Background())
+	proc.handleMessage(context.Background(), BuildStatusMsg(signer1, s))
+	require.Equal(t, map[types.NodeID]Participant{
+		signer1.NodeID(): {ID: signer1.NodeID(), Rounds: []uint32{preRound, statusRound}, Honest: true, Current: true},
+		signer2.NodeID(): {ID: signer2.NodeID(), Rounds: []uint32{preRound}, Honest: true},
+	}, byID())
 }
 
 func TestConsensusProcess_nextRound(t *testing.T) {
@@ -375,9 +555,10 @@ func generateConsensusProcessWithConfig(tb testing.TB, cfg config.Config, inbox
 		edVerifier,
 		NewEligibilityTracker(cfg.N),
 		types.BytesToNodeID(edPubkey.Bytes()),
-		noopPubSub(tb),
+		&memPublisher{},
 		comm,
 		truer{},
+		nil,
 		newRoundClockFromCfg(logger, cfg),
 		logger.WithName(edPubkey.String()),
 	)
@@ -492,6 +673,27 @@ func TestConsensusProcess_procPre(t *testing.T) {
 	require.True(t, proc.preRoundTracker.coinflip)
 }
 
+func TestConsensusProcess_Provenance(t *testing.T) {
+	c := config.Config{N: 10, RoundDuration: 200 * time.Millisecond, ExpectedLeaders: 5, LimitIterations: 1, LimitConcurrent: 1, Hdist: 20, ValueProvenance: true}
+	proc := generateConsensusProcessWithConfig(t, c, make(chan any, 10))
+	first, err := signing.NewEdSigner()
+	require.NoError(t, err)
+	second, err := signing.NewEdSigner()
+	require.NoError(t, err)
+
+	proc.processMsg(context.Background(), BuildPreRoundMsg(first, NewSetFromValues(types.ProposalID{1}, types.ProposalID{2}), types.EmptyVrfSignature))
+	proc.processMsg(context.Background(), BuildPreRoundMsg(second, NewSetFromValues(types.ProposalID{2}, types.ProposalID{3}), types.EmptyVrfSignature))
+	require.Equal(t, map[types.ProposalID]types.NodeID{
+		types.ProposalID{1}: first.NodeID(),
+		types.ProposalID{2}: first.NodeID(),
+		types.ProposalID{3}: second.NodeID(),
+	}, proc.Provenance())
+
+	disabled := generateConsensusProcess(t)
+	disabled.processMsg(context.Background(), BuildPreRoundMsg(first, NewSetFromValues(types.ProposalID{1}), types.EmptyVrfSignature))
+	require.Nil(t, disabled.Provenance())
+}
+
 func TestConsensusProcess_procStatus(t *testing.T) {
 	proc := generateConsensusProcess(t)
 	proc.beginStatusRound(context.Background())
@@ -525,6 +727,32 @@ func TestConsensusProcess_procProposal(t *testing.T) {
 	require.Equal(t, 1, mpt.countOnLateProposal)
 }
 
+func TestConsensusProcess_ValidValue(t *testing.T) {
+	invalid := types.ProposalID{3}
+	proc := generateConsensusProcess(t)
+	proc.validValue = func(id types.ProposalID) bool { return id != invalid }
+
+	require.NoError(t, proc.SetInitialValues(NewSetFromValues(types.ProposalID{1}, types.ProposalID{2}, invalid)))
+	require.True(t, NewSetFromValues(types.ProposalID{1}, types.ProposalID{2}).Equals(proc.value))
+
+	signer, err := signing.NewEdSigner()
+	require.NoError(t, err)
+	proc.beginStatusRound(context.Background())
+	proc.processStatusMsg(context.Background(), BuildStatusMsg(signer, NewSetFromValues(types.ProposalID{1}, invalid)))
+	require.Empty(t, proc.statusesTracker.statuses)
+	proc.processStatusMsg(context.Background(), BuildStatusMsg(signer, NewSetFromValues(types.ProposalID{1})))
+	require.Len(t, proc.statusesTracker.statuses, 1)
+
+	proc.advanceToNextRound(context.Background())
+	proc.advanceToNextRound(context.Background())
+	mpt := &mockProposalTracker{}
+	proc.proposalTracker = mpt
+	proc.processProposalMsg(context.Background(), BuildProposalMsg(signer, NewSetFromValues(invalid)))
+	require.Equal(t, 0, mpt.countOnProposal)
+	proc.processProposalMsg(context.Background(), BuildProposalMsg(signer, NewSetFromValues(types.ProposalID{2})))
+	require.Equal(t, 1, mpt.countOnProposal)
+}
+
 func TestConsensusProcess_procCommit(t *testing.T) {
 	proc := generateConsensusProcess(t)
 	proc.advanceToNextRound(context.Background())
@@ -539,6 +767,216 @@ func TestConsensusProcess_procCommit(t *testing.T) {
 	require.Equal(t, 1, mct.countOnCommit)
 }
 
+// manualClock ends a round when the test calls end.
+type manualClock struct {
+	mu   sync.Mutex
+	ends map[uint32]chan struct{}
+}
+
+func (c *manualClock) AwaitWakeup() <-chan struct{} {
+	return c.AwaitEndOfRound(Wakeup)
+}
+
+func (c *manualClock) RoundEnd(uint32) time.Time {
+	return time.Now()
+}
+
+func (c *manualClock) AwaitEndOfRound(round uint32) <-chan struct{} {
+	return c.endOf(round)
+}
+
+func (c *manualClock) endOf(round uint32) chan struct{} {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.ends == nil {
+		c.ends = map[uint32]chan struct{}{}
+	}
+	if _, ok := c.ends[round]; !ok {
+		c.ends[round] = make(chan struct{})
+	}
+	return c.ends[round]
+}
+
+func (c *manualClock) end(round uint32) {
+	close(c.endOf(round))
+}
+
+func TestConsensusProcess_Shutdown(t *testing.T) {
+	c := config.Config{N: 4, RoundDuration: time.Hour, ExpectedLeaders: 2, LimitIterations: 1, LimitConcurrent: 1, Hdist: 20}
+
+	t.Run("at the end of the round", func(t *testing.T) {
+		proc := generateConsensusProcessWithConfig(t, c, make(chan any, 10))
+		clock := &manualClock{}
+		proc.clock = clock
+		proc.publisher = &mockP2p{}
+		proc.Start()
+		clock.end(preRound)
+		require.Eventually(t, func() bool { return proc.getRound() == statusRound }, time.Second, 10*time.Millisecond)
+
+		stopped := make(chan error, 1)
+		go func() { stopped <- proc.Shutdown(context.Background()) }()
+		require.Never(t, func() bool { return len(stopped) > 0 }, 100*time.Millisecond, 10*time.Millisecond)
+		require.False(t, proc.terminating())
+
+		clock.end(statusRound)
+		select {
+		case err := <-stopped:
+			require.NoError(t, err)
+		case <-time.After(time.Second):
+			require.Fail(t, "timed out waiting for shutdown")
+		}
+		require.True(t, proc.terminating())
+		require.Equal(t, statusRound, proc.getRound())
+		require.Empty(t, proc.comm.report)
+	})
+
+	t.Run("timeout", func(t *testing.T) {
+		proc := generateConsensusProcessWithConfig(t, c, make(chan any, 10))
+		proc.clock = &manualClock{}
+		proc.publisher = &mockP2p{}
+		proc.Start()
+
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+		require.ErrorIs(t, proc.Shutdown(ctx), context.DeadlineExceeded)
+		require.True(t, proc.terminating())
+		require.Equal(t, preRound, proc.getRound())
+	})
+
+	t.Run("not started", func(t *testing.T) {
+		proc := generateConsensusProcessWithConfig(t, c, make(chan any, 10))
+		require.NoError(t, proc.Shutdown(context.Background()))
+		require.ErrorIs(t, proc.ctx.Err(), context.Canceled)
+	})
+}
+
+func TestConsensusProcess_CommitThresholdOverride(t *testing.T) {
+	for _, tc := range []struct {
+		desc       string
+		thresholds map[string]int
+		enough     bool
+	}{
+		{desc: "majority", enough: true},
+		{desc: "override", thresholds: map[string]int{config.ThresholdCommit: 8}},
+	} {
+		tc := tc
+		t.Run(tc.desc, func(t *testing.T) {
+			c := config.Config{N: 10, RoundDuration: 2 * time.Second, ExpectedLeaders: 5, LimitIterations: 1000, LimitConcurrent: 1000, Hdist: 20, RoundThresholds: tc.thresholds}
+			require.NoError(t, c.Validate())
+			proc := generateConsensusProcessWithConfig(t, c, make(chan any, 10))
+			s := NewSetFromValues(types.ProposalID{1})
+			proc.setRound(commitRound)
+			proc.proposalTracker = &mockProposalTracker{proposedSet: s}
+			proc.beginCommitRound(context.Background())
+
+			// a majority of the committee commits
+			for i := 0; i < c.N/2+1; i++ {
+				signer, err := signing.NewEdSigner()
+				require.NoError(t, err)
+				m := BuildCommitMsg(signer, s)
+				proc.eTracker.Track(m.SmesherID, m.Round, m.Eligibility.Count, true)
+				proc.commitTracker.OnCommit(context.Background(), m)
+			}
+			require.Equal(t, tc.enough, proc.commitTracker.HasEnoughCommits())
+		})
+	}
+}
+
+func TestConsensusProcess_RoundWait(t *testing.T) {
+	c := config.Config{N: 10, RoundDuration: time.Millisecond, ExpectedLeaders: 5, LimitIterations: 1000, LimitConcurrent: 1000, Hdist: 20}
+	proc := generateConsensusProcessWithConfig(t, c, make(chan any, 10))
+	proc.setRound(commitRound)
+	require.Eventually(t, func() bool {
+		return time.Now().After(proc.clock.RoundEnd(notifyRound))
+	}, time.Second, time.Millisecond)
+
+	signer, err := signing.NewEdSigner()
+	require.NoError(t, err)
+	m := BuildCommitMsg(signer, NewDefaultEmptySet())
+	mct := &mockCommitTracker{}
+	proc.commitTracker = mct
+	proc.processCommitMsg(context.Background(), m)
+	require.Empty(t, proc.getRoundWaits())
+
+	mct.hasEnoughCommits = true
+	proc.processCommitMsg(context.Background(), m)
+	proc.processCommitMsg(context.Background(), m)
+	proc.onRoundEnd(context.Background())
+	waits := proc.getRoundWaits()
+	require.Len(t, waits, 1)
+	require.Equal(t, commitRound, waits[0].round)
+	require.GreaterOrEqual(t, waits[0].wait, time.Duration(0))
+	require.False(t, waits[0].timedOut)
+
+	// notify round ends without enough notifications
+	proc.advanceToNextRound(context.Background())
+	proc.onRoundEnd(context.Background())
+	waits = proc.getRoundWaits()
+	require.Len(t, waits, 2)
+	require.Equal(t, notifyRound, waits[1].round)
+	require.GreaterOrEqual(t, waits[1].wait, time.Duration(0))
+	require.True(t, waits[1].timedOut)
+}
+
+func TestConsensusProcess_AuditLog(t *testing.T) {
+	c := config.Config{N: 10, RoundDuration: 2 * time.Second, ExpectedLeaders: 5, LimitIterations: 1000, LimitConcurrent: 1000, Hdist: 20, AuditLogSize: 1}
+	proc := generateConsensusProcessWithConfig(t, c, make(chan any, 10))
+	s := NewSetFromValues(types.ProposalID{1})
+	proc.setRound(commitRound)
+	proc.proposalTracker = &mockProposalTracker{proposedSet: s}
+	proc.beginCommitRound(context.Background())
+
+	signers := make([]*signing.EdSigner, c.N/2+2)
+	for i := range signers {
+		signer, err := signing.NewEdSigner()
+		require.NoError(t, err)
+		signers[i] = signer
+	}
+	sortedIDs := func(signers []*signing.EdSigner) []types.NodeID {
+		ids := make([]types.NodeID, 0, len(signers))
+		for _, signer := range signers {
+			ids = append(ids, signer.NodeID())
+		}
+		sort.Slice(ids, func(i, j int) bool { return bytes.Compare(ids[i].Bytes(), ids[j].Bytes()) < 0 })
+		return ids
+	}
+
+	// the commit of the last signer arrives after the threshold is crossed and is not recorded
+	for _, signer := range signers {
+		m := BuildCommitMsg(signer, s)
+		proc.eTracker.Track(m.SmesherID, m.Round, m.Eligibility.Count, true)
+		proc.processCommitMsg(context.Background(), m)
+	}
+	require.Equal(t, []AuditEntry{{
+		Round:   commitRound,
+		Type:    commit,
+		Senders: sortedIDs(signers[:c.N/2+1]),
+		SetID:   s.ID(),
+	}}, proc.AuditLog())
+
+	// the log retains a single entry, the commit entry is dropped on termination
+	proc.notifyTracker = newNotifyTracker(logtest.New(t), notifyRound, make(chan *types.MalfeasanceGossip), proc.eTracker, proc.cfg.N)
+	proc.advanceToNextRound(context.Background())
+	for _, signer := range signers[:c.N/2+1] {
+		m := BuildNotifyMsg(signer, s)
+		proc.eTracker.Track(m.SmesherID, m.Round, m.Eligibility.Count, true)
+		proc.processNotifyMsg(context.Background(), m)
+	}
+	require.True(t, proc.terminating())
+	require.Equal(t, []AuditEntry{{
+		Round:   notifyRound,
+		Type:    notify,
+		Senders: sortedIDs(signers[:c.N/2+1]),
+		SetID:   s.ID(),
+	}}, proc.AuditLog())
+
+	disabled := generateConsensusProcess(t)
+	disabled.setRound(commitRound)
+	disabled.commitTracker = &mockCommitTracker{hasEnoughCommits: true, certificate: &Certificate{AggMsgs: &AggregatedMessages{}}}
+	disabled.processCommitMsg(context.Background(), BuildCommitMsg(signers[0], s))
+	require.Nil(t, disabled.AuditLog())
+}
+
 func TestConsensusProcess_procNotify(t *testing.T) {
 	proc := generateConsensusProcess(t)
 	proc.notifyTracker = newNotifyTracker(logtest.New(t), 7, make(chan *types.MalfeasanceGossip), proc.eTracker, proc.cfg.N)
@@ -577,6 +1015,39 @@ func TestConsensusProcess_Termination(t *testing.T) {
 	require.Equal(t, true, proc.terminating())
 }
 
+func TestConsensusProcess_NoConflictingDecision(t *testing.T) {
+	proc := generateConsensusProcess(t)
+	core, logs := observer.New(zapcore.ErrorLevel)
+	proc.Log = log.NewFromLog(zap.New(core))
+	proc.notifyTracker = newNotifyTracker(logtest.New(t), notifyRound, make(chan *types.MalfeasanceGossip), proc.eTracker, proc.cfg.N)
+	proc.advanceToNextRound(context.Background())
+
+	notifyAll := func(s *Set) {
+		for i := 0; i < proc.cfg.N/2+1; i++ {
+			signer, err := signing.NewEdSigner()
+			require.NoError(t, err)
+			m := BuildNotifyMsg(signer, s)
+			proc.eTracker.Track(m.SmesherID, m.Round, m.Eligibility.Count, true)
+			proc.processNotifyMsg(context.Background(), m)
+		}
+	}
+
+	s := NewSetFromValues(types.ProposalID{1})
+	notifyAll(s)
+	require.True(t, proc.terminating())
+	require.Len(t, proc.comm.report, 1)
+	require.Zero(t, logs.Len())
+
+	// late notifications for a different set reach the threshold after termination
+	notifyAll(NewSetFromValues(types.ProposalID{2}))
+	require.True(t, s.Equals(proc.decided))
+	require.True(t, s.Equals(proc.value))
+	require.Len(t, proc.comm.report, 1)
+	out := <-proc.comm.report
+	require.True(t, s.Equals(out.set))
+	require.Equal(t, 1, logs.FilterMessage("refusing to decide a different set after termination").Len())
+}
+
 func TestConsensusProcess_currentRound(t *testing.T) {
 	proc := generateConsensusProcess(t)
 	proc.advanceToNextRound(context.Background())
@@ -589,6 +1060,19 @@ func TestConsensusProcess_currentRound(t *testing.T) {
 	require.Equal(t, notifyRound, proc.currentRound())
 }
 
+func TestConsensusProcess_Round(t *testing.T) {
+	proc := generateConsensusProcess(t)
+	k, phase := proc.Round()
+	require.Equal(t, uint32(preRound), k)
+	require.Equal(t, pre, phase)
+	for i, expected := range []MessageType{status, proposal, commit, notify, status, proposal} {
+		proc.advanceToNextRound(context.Background())
+		k, phase = proc.Round()
+		require.Equal(t, uint32(i), k)
+		require.Equal(t, expected, phase)
+	}
+}
+
 func TestConsensusProcess_onEarlyMessage(t *testing.T) {
 	r := require.New(t)
 	proc := generateConsensusProcess(t)
@@ -597,11 +1081,11 @@ func TestConsensusProcess_onEarlyMessage(t *testing.T) {
 	m := BuildPreRoundMsg(signer1, NewDefaultEmptySet(), types.EmptyVrfSignature)
 	proc.advanceToNextRound(context.Background())
 	proc.onEarlyMessage(context.Background(), &Message{})
-	r.Len(proc.pending, 0)
+	r.Zero(proc.pending.size())
 	proc.onEarlyMessage(context.Background(), m)
-	r.Len(proc.pending, 1)
+	r.Equal(1, proc.pending.size())
 	proc.onEarlyMessage(context.Background(), m)
-	r.Len(proc.pending, 1)
+	r.Equal(1, proc.pending.size())
 	signer2, err := signing.NewEdSigner()
 	require.NoError(t, err)
 	m2 := BuildPreRoundMsg(signer2, NewDefaultEmptySet(), types.EmptyVrfSignature)
@@ -610,11 +1094,11 @@ func TestConsensusProcess_onEarlyMessage(t *testing.T) {
 	require.NoError(t, err)
 	m3 := BuildPreRoundMsg(signer3, NewDefaultEmptySet(), types.EmptyVrfSignature)
 	proc.onEarlyMessage(context.Background(), m3)
-	r.Len(proc.pending, 3)
+	r.Equal(3, proc.pending.size())
 	proc.onRoundBegin(context.Background())
 
 	// make sure we wait enough for the go routine to be executed
-	r.Eventually(func() bool { return len(proc.pending) == 0 },
+	r.Eventually(func() bool { return proc.pending.size() == 0 },
 		time.Second, 100*time.Millisecond, "expected proc.pending to have zero length")
 }
 
@@ -682,6 +1166,36 @@ func TestConsensusProcess_beginProposalRound(t *testing.T) {
 	require.Nil(t, proc.statusesTracker)
 }
 
+func TestConsensusProcess_beginProposalRound_NotEligible(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	proc := generateConsensusProcess(t)
+	network := &mockP2p{}
+	proc.publisher = network
+	proc.round = proposalRound
+
+	mo := mocks.NewMockRolacle(ctrl)
+	mo.EXPECT().IsIdentityActiveOnConsensusView(gomock.Any(), gomock.Any(), proc.layer).Return(true, nil).Times(1)
+	mo.EXPECT().Proof(gomock.Any(), proc.layer, proc.getRound()).Return(types.EmptyVrfSignature, nil).Times(1)
+	mo.EXPECT().CalcEligibility(gomock.Any(), proc.layer, proc.getRound(), gomock.Any(), proc.nid, gomock.Any()).Return(uint16(0), nil).Times(1)
+	proc.oracle = mo
+
+	statusTracker := newStatusTracker(logtest.New(t), statusRound, make(chan *types.MalfeasanceGossip), proc.eTracker, 1, 1)
+	signer, err := signing.NewEdSigner()
+	require.NoError(t, err)
+	m := BuildStatusMsg(signer, NewSetFromValues(types.ProposalID{1}))
+	proc.eTracker.Track(m.SmesherID, m.Round, m.Eligibility.Count, true)
+	statusTracker.RecordStatus(context.Background(), m)
+	statusTracker.AnalyzeStatusMessages(func(*Message) bool { return true })
+	require.True(t, statusTracker.IsSVPReady())
+	proc.statusesTracker = statusTracker
+
+	// the node is not a leader of the proposal round, it doesn't propose
+	proc.beginProposalRound(context.Background())
+	require.Zero(t, network.getCount())
+	require.Nil(t, proc.statusesTracker)
+}
+
 func TestConsensusProcess_beginCommitRound(t *testing.T) {
 	ctrl := gomock.NewController(t)
 
@@ -725,12 +1239,11 @@ func (m *mockNet) Publish(ctx context.Context, protocol string, payload []byte)
 func TestConsensusProcess_handlePending(t *testing.T) {
 	proc := generateConsensusProcess(t)
 	const count = 5
-	pending := make(map[types.NodeID]*Message)
+	pending := make([]any, 0, count)
 	for i := 0; i < count; i++ {
 		signer, err := signing.NewEdSigner()
 		require.NoError(t, err)
-		m := BuildStatusMsg(signer, NewSetFromValues(types.ProposalID{1}))
-		pending[signer.NodeID()] = m
+		pending = append(pending, BuildStatusMsg(signer, NewSetFromValues(types.ProposalID{1})))
 	}
 	proc.handlePending(pending)
 	require.Equal(t, count, len(proc.comm.inbox))
@@ -4,7 +4,11 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sort"
 	"sync"
+	"time"
+
+	"golang.org/x/exp/maps"
 
 	"github.com/spacemeshos/go-spacemesh/codec"
 	"github.com/spacemeshos/go-spacemesh/common/types"
@@ -17,7 +21,12 @@ import (
 	"github.com/spacemeshos/go-spacemesh/system"
 )
 
-const inboxCapacity = 1024 // inbox size per instance
+const (
+	inboxCapacity = 1024 // inbox size per instance
+	// dispatchInterval is how often the broker retries to deliver the queued messages
+	// of instances that didn't have room in their inbox.
+	dispatchInterval = 10 * time.Millisecond
+)
 
 type validator interface {
 	Validate(context.Context, *Message) bool
@@ -26,6 +35,8 @@ type validator interface {
 
 // Broker is the dispatcher of incoming Hare messages.
 // The broker validates that the sender is eligible and active and forwards the message to the corresponding outbox.
+// Valid messages are queued per instance and delivered round-robin across instances, one message per instance
+// at a time, so that an instance flooded with messages doesn't starve the others.
 type Broker struct {
 	log.Log
 	mu sync.RWMutex
@@ -38,11 +49,13 @@ type Broker struct {
 	nodeSyncState system.SyncStateProvider // provider function to check if the node is currently synced
 	publisher     pubsub.Publisher
 	outbox        map[types.LayerID]chan any
+	queued        map[types.LayerID][]any // valid messages waiting for room in the outbox of the instance
 	trackers      map[types.LayerID]*EligibilityTracker
-	pending       map[types.LayerID][]any // the buffer of pending early messages for the next layer
-	latestLayer   types.LayerID           // the latest layer to attempt register (successfully or unsuccessfully)
+	pending       map[types.LayerID]*boundedBuffer // the buffer of pending early messages for the next layer
+	latestLayer   types.LayerID                    // the latest layer to attempt register (successfully or unsuccessfully)
 	minDeleted    types.LayerID
-	limit         int // max number of simultaneous consensus processes
+	limit         int                     // max number of simultaneous consensus processes
+	leaders       map[types.NodeID]uint64 // number of proposal rounds each identity was the accepted leader
 
 	ctx    context.Context
 	cancel context.CancelFunc
@@ -71,10 +84,12 @@ func newBroker(
 		publisher:     publisher,
 		trackers:      map[types.LayerID]*EligibilityTracker{},
 		outbox:        make(map[types.LayerID]chan any),
-		pending:       make(map[types.LayerID][]any),
+		queued:        make(map[types.LayerID][]any),
+		pending:       make(map[types.LayerID]*boundedBuffer),
 		latestLayer:   types.GetEffectiveGenesis(),
 		limit:         limit,
 		minDeleted:    types.GetEffectiveGenesis(),
+		leaders:       make(map[types.NodeID]uint64),
 	}
 	b.ctx, b.cancel = context.WithCancel(context.Background())
 	return b
@@ -89,14 +104,71 @@ func (b *Broker) Start(ctx context.Context) {
 			b.cancel()
 		}
 		b.ctx, b.cancel = context.WithCancel(ctx)
+		go b.dispatchLoop(b.ctx)
 	})
 }
 
+func (b *Broker) dispatchLoop(ctx context.Context) {
+	ticker := time.NewTicker(dispatchInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			b.dispatch()
+		}
+	}
+}
+
+// enqueue adds a valid message to the queue of the instance.
+// It returns false if the instance is not registered or its queue is full.
+func (b *Broker) enqueue(id types.LayerID, msg any) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if _, exist := b.outbox[id]; !exist || len(b.queued[id]) >= inboxCapacity {
+		return false
+	}
+	b.queued[id] = append(b.queued[id], msg)
+	return true
+}
+
+// dispatch moves the queued messages to the outboxes of the instances. Every pass delivers at most one message
+// to each instance, in the order of the layers, and it never waits for an instance whose outbox is full.
+func (b *Broker) dispatch() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	layers := maps.Keys(b.queued)
+	sort.Slice(layers, func(i, j int) bool { return layers[i].Before(layers[j]) })
+	for delivered := true; delivered; {
+		delivered = false
+		for _, lid := range layers {
+			queue := b.queued[lid]
+			if len(queue) == 0 {
+				continue
+			}
+			select {
+			case b.outbox[lid] <- queue[0]:
+				queue[0] = nil
+				b.queued[lid] = queue[1:]
+				delivered = true
+			default:
+			}
+		}
+	}
+	for lid, queue := range b.queued {
+		if len(queue) == 0 {
+			delete(b.queued, lid)
+		}
+	}
+}
+
 var (
 	errUnregistered      = errors.New("layer is unregistered")
 	errNotSynced         = errors.New("layer is not synced")
 	errFutureMsg         = errors.New("future message")
 	errRegistration      = errors.New("failed during registration")
+	errAlreadyRegistered = errors.New("layer is already registered")
 	errInstanceNotSynced = errors.New("instance not synchronized")
 	errClosed            = errors.New("closed")
 )
@@ -221,13 +293,14 @@ func (b *Broker) HandleMessage(ctx context.Context, _ p2p.Peer, msg []byte) erro
 	}
 	logger.With().Debug("broker forwarding message to outbox",
 		log.Int("queue_size", len(out)))
-	select {
-	case out <- hareMsg:
-	case <-ctx.Done():
-		return ctx.Err()
-	case <-b.ctx.Done():
-		return errClosed
+	if !b.enqueue(msgLayer, hareMsg) {
+		// the message is valid and still relayed, only this node can't keep up with it
+		droppedMessages.Inc()
+		logger.With().Warning("inbox is full, ignoring message",
+			log.Int("inbox_capacity", inboxCapacity))
+		return nil
 	}
+	b.dispatch()
 	return nil
 }
 
@@ -316,13 +389,15 @@ func (b *Broker) HandleEligibility(ctx context.Context, em *types.HareEligibilit
 	}
 	b.Log.WithContext(ctx).With().Debug("broker forwarding gossip eligibility to consensus process",
 		log.Int("queue_size", len(out)))
-	select {
-	case out <- em:
+	if !b.enqueue(lid, em) {
+		droppedMessages.Inc()
+		b.Log.WithContext(ctx).With().Warning("inbox is full, ignoring gossip eligibility",
+			em.Layer,
+			log.Int("inbox_capacity", inboxCapacity))
 		return true
-	case <-ctx.Done():
-	case <-b.ctx.Done():
 	}
-	return false
+	b.dispatch()
+	return true
 }
 
 func (b *Broker) getInbox(id types.LayerID) chan any {
@@ -339,25 +414,43 @@ func (b *Broker) handleEarlyMessage(logger log.Log, layer types.LayerID, nodeID
 	b.mu.Lock()
 	defer b.mu.Unlock()
 	if _, exist := b.pending[layer]; !exist { // create buffer if first msg
-		b.pending[layer] = make([]any, 0, inboxCapacity)
+		// we want to write all buffered messages to a chan with InboxCapacity len
+		// hence, we limit the buffer for pending messages
+		b.pending[layer] = newBoundedBuffer(inboxCapacity, false, bufferOptions(b.cfg)...)
 	}
-
-	// we want to write all buffered messages to a chan with InboxCapacity len
-	// hence, we limit the buffer for pending messages
-	if len(b.pending[layer]) == inboxCapacity {
+	if err := b.pending[layer].add(nodeID, msg); err != nil {
 		logger.With().Warning("too many pending messages, ignoring message",
 			log.Int("inbox_capacity", inboxCapacity),
 			log.Stringer("smesher", nodeID))
-		return nil
 	}
-	b.pending[layer] = append(b.pending[layer], msg)
 	return nil
 }
 
-func (b *Broker) CleanOldLayers(current types.LayerID) {
+// CleanOldLayers frees the state of the layers that are no longer needed.
+// instances older than the retention window are evicted even if they did not terminate,
+// late messages for them are rejected by the timing validation.
+// It returns the evicted layers, their consensus processes no longer receive messages and should be stopped.
+func (b *Broker) CleanOldLayers(current types.LayerID) []types.LayerID {
 	b.mu.Lock()
 	defer b.mu.Unlock()
 
+	var evicted []types.LayerID
+	if b.cfg.RetainLayers > 0 && current.After(types.LayerID(b.cfg.RetainLayers)) {
+		oldest := current.Sub(b.cfg.RetainLayers)
+		for lid := range b.outbox {
+			if lid.Before(oldest) {
+				delete(b.outbox, lid)
+				delete(b.queued, lid)
+				evicted = append(evicted, lid)
+				b.With().Info("evicted layer outside of retention window",
+					lid,
+					log.Stringer("current", current),
+					log.Uint32("retain_layers", b.cfg.RetainLayers),
+				)
+			}
+		}
+	}
+
 	for i := b.minDeleted + 1; i <= current; i++ {
 		if _, exist := b.outbox[i]; !exist { // unregistered
 			b.minDeleted = i
@@ -368,17 +461,27 @@ func (b *Broker) CleanOldLayers(current types.LayerID) {
 	for lid := range b.trackers {
 		if lid <= b.minDeleted {
 			delete(b.trackers, lid)
+		}
+	}
+	for lid := range b.pending {
+		if lid <= b.minDeleted {
 			delete(b.pending, lid)
 		}
 	}
+	return evicted
 }
 
 // Register a layer to receive messages
 // Note: the registering instance is assumed to be started and accepting messages.
+// It returns an error if the layer is already registered, the layer must be unregistered first.
 func (b *Broker) Register(ctx context.Context, id types.LayerID) (chan any, *EligibilityTracker, error) {
 	b.mu.Lock()
 	defer b.mu.Unlock()
 
+	if _, exist := b.outbox[id]; exist {
+		return nil, nil, errAlreadyRegistered
+	}
+
 	if !id.After(b.latestLayer) { // should expect to update only newer layers
 		b.WithContext(ctx).With().Error("tried to update a previous layer",
 			log.Stringer("this_layer", id),
@@ -400,15 +503,18 @@ func (b *Broker) Register(ctx context.Context, id types.LayerID) (chan any, *Eli
 			min = types.MinLayer(min, lid)
 		}
 		delete(b.outbox, min)
+		delete(b.queued, min)
 		b.minDeleted = min
 		b.With().Info("unregistered layer due to maximum concurrent processes", min)
 	}
 	outboxCh := make(chan any, inboxCapacity)
 	b.outbox[id] = outboxCh
-	for _, mOut := range b.pending[id] {
-		outboxCh <- mOut
+	if pending, exist := b.pending[id]; exist {
+		for _, mOut := range pending.drain() {
+			outboxCh <- mOut
+		}
+		delete(b.pending, id)
 	}
-	delete(b.pending, id)
 	if _, ok := b.trackers[id]; !ok {
 		b.trackers[id] = NewEligibilityTracker(b.cfg.N)
 	}
@@ -420,9 +526,28 @@ func (b *Broker) Unregister(ctx context.Context, id types.LayerID) {
 	b.mu.Lock()
 	defer b.mu.Unlock()
 	delete(b.outbox, id)
+	delete(b.queued, id)
 	b.WithContext(ctx).With().Debug("hare broker unregistered layer", id)
 }
 
+func (b *Broker) recordLeader(id types.NodeID) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.leaders[id]++
+}
+
+// LeaderStats returns how many times each identity was the accepted leader of a proposal round,
+// across all consensus processes of this node. A skewed distribution hints at a biased oracle.
+func (b *Broker) LeaderStats() map[types.NodeID]uint64 {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	stats := make(map[types.NodeID]uint64, len(b.leaders))
+	for id, count := range b.leaders {
+		stats[id] = count
+	}
+	return stats
+}
+
 // Synced returns true if the given layer is synced, false otherwise.
 func (b *Broker) Synced(ctx context.Context, id types.LayerID) bool {
 	return b.nodeSyncState.IsSynced(ctx) && b.nodeSyncState.IsBeaconSynced(id.GetEpoch())
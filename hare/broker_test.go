@@ -70,6 +70,90 @@ func TestBroker_Received(t *testing.T) {
 	waitForMessages(t, inbox, lid, 1)
 }
 
+// test that a process that doesn't consume its messages doesn't starve the other processes.
+func TestBroker_FullInbox(t *testing.T) {
+	broker := buildBroker(t, t.Name())
+	broker.mockSyncS.EXPECT().IsSynced(gomock.Any()).Return(true).AnyTimes()
+	broker.mockSyncS.EXPECT().IsBeaconSynced(gomock.Any()).Return(true).AnyTimes()
+	broker.mockStateQ.EXPECT().IsIdentityActiveOnConsensusView(gomock.Any(), gomock.Any(), gomock.Any()).Return(true, nil).AnyTimes()
+	broker.mockMesh.EXPECT().GetMalfeasanceProof(gomock.Any()).AnyTimes()
+	broker.Start(context.Background())
+	t.Cleanup(broker.Close)
+
+	flooded, _, err := broker.Register(context.Background(), instanceID1)
+	require.NoError(t, err)
+	inbox, _, err := broker.Register(context.Background(), instanceID2)
+	require.NoError(t, err)
+
+	// the inbox and the queue of the flooded process are filled, the rest is dropped
+	const dropped = 10
+	done := make(chan error)
+	go func() {
+		for i := 0; i < 2*inboxCapacity+dropped; i++ {
+			if err := broker.HandleMessage(context.Background(), "", createMessage(t, instanceID1)); err != nil {
+				done <- err
+				return
+			}
+			// the other process keeps receiving its messages while the first one is flooded
+			if i%inboxCapacity == 0 {
+				if err := broker.HandleMessage(context.Background(), "", createMessage(t, instanceID2)); err != nil {
+					done <- err
+					return
+				}
+			}
+		}
+		done <- nil
+	}()
+	select {
+	case err := <-done:
+		require.NoError(t, err, "dropped messages are not rejected")
+	case <-time.After(10 * time.Second):
+		require.FailNow(t, "broker blocked on the full inbox")
+	}
+	waitForMessages(t, inbox, instanceID2, 3)
+	require.Len(t, flooded, inboxCapacity)
+	broker.mu.RLock()
+	require.Len(t, broker.queued[instanceID1], inboxCapacity)
+	broker.mu.RUnlock()
+
+	// queued messages are delivered once the process catches up
+	waitForMessages(t, flooded, instanceID1, 2*inboxCapacity)
+	require.Empty(t, flooded)
+}
+
+func TestBroker_DispatchRoundRobin(t *testing.T) {
+	broker := buildBroker(t, t.Name())
+	broker.mockSyncS.EXPECT().IsSynced(gomock.Any()).Return(true).AnyTimes()
+	broker.mockSyncS.EXPECT().IsBeaconSynced(gomock.Any()).Return(true).AnyTimes()
+	first, _, err := broker.Register(context.Background(), instanceID1)
+	require.NoError(t, err)
+	second, _, err := broker.Register(context.Background(), instanceID2)
+	require.NoError(t, err)
+
+	// both inboxes are full
+	for i := 0; i < inboxCapacity; i++ {
+		first <- &Message{}
+		second <- &Message{}
+	}
+	for i := 0; i < 3; i++ {
+		require.True(t, broker.enqueue(instanceID1, i))
+	}
+	require.True(t, broker.enqueue(instanceID2, 0))
+
+	// a single free slot in each inbox is filled with the head of each queue
+	<-first
+	<-second
+	broker.dispatch()
+	require.Len(t, first, inboxCapacity)
+	require.Len(t, second, inboxCapacity)
+	require.Len(t, broker.queued[instanceID1], 2)
+	require.Empty(t, broker.queued[instanceID2])
+
+	broker.Unregister(context.Background(), instanceID1)
+	require.Empty(t, broker.queued[instanceID1])
+	require.False(t, broker.enqueue(instanceID1, 0))
+}
+
 // test that after registering the maximum number of protocols,
 // the earliest one gets unregistered in favor of the newest one.
 func TestBroker_MaxConcurrentProcesses(t *testing.T) {
@@ -373,6 +457,7 @@ func TestBroker_HandleEligibility(t *testing.T) {
 		broker.mockStateQ.EXPECT().IsIdentityActiveOnConsensusView(gomock.Any(), gomock.Any(), gomock.Any()).Return(false, errUnknown)
 		require.False(t, broker.HandleEligibility(context.Background(), em))
 		require.Len(t, inbox, 0)
+		broker.Unregister(context.Background(), instanceID1)
 	})
 
 	t.Run("identity not active", func(t *testing.T) {
@@ -384,6 +469,7 @@ func TestBroker_HandleEligibility(t *testing.T) {
 		broker.mockStateQ.EXPECT().IsIdentityActiveOnConsensusView(gomock.Any(), gomock.Any(), gomock.Any()).Return(false, nil)
 		require.False(t, broker.HandleEligibility(context.Background(), em))
 		require.Len(t, inbox, 0)
+		broker.Unregister(context.Background(), instanceID1)
 	})
 
 	t.Run("identity not eligible", func(t *testing.T) {
@@ -395,6 +481,7 @@ func TestBroker_HandleEligibility(t *testing.T) {
 		broker.mockStateQ.EXPECT().IsIdentityActiveOnConsensusView(gomock.Any(), gomock.Any(), gomock.Any()).Return(true, nil)
 		require.False(t, broker.HandleEligibility(context.Background(), em))
 		require.Len(t, inbox, 0)
+		broker.Unregister(context.Background(), instanceID1)
 	})
 
 	t.Run("identity eligible", func(t *testing.T) {
@@ -426,15 +513,41 @@ func TestBroker_Register(t *testing.T) {
 	msg := BuildPreRoundMsg(signer, NewSetFromValues(types.RandomProposalID()), types.EmptyVrfSignature)
 
 	broker.mu.Lock()
-	broker.pending[instanceID1] = []any{msg, msg}
+	broker.pending[instanceID1] = newBoundedBuffer(inboxCapacity, false)
+	require.NoError(t, broker.pending[instanceID1].add(signer.NodeID(), msg))
+	require.NoError(t, broker.pending[instanceID1].add(signer.NodeID(), msg))
 	broker.mu.Unlock()
 
 	broker.Register(context.Background(), instanceID1)
 
 	broker.mu.RLock()
 	assert.Equal(t, 2, len(broker.outbox[instanceID1]))
-	assert.Equal(t, 0, len(broker.pending[instanceID1]))
+	assert.NotContains(t, broker.pending, instanceID1)
+	broker.mu.RUnlock()
+}
+
+func TestBroker_RegisterTwice(t *testing.T) {
+	broker := buildBroker(t, t.Name())
+	broker.mockSyncS.EXPECT().IsSynced(gomock.Any()).Return(true).AnyTimes()
+	broker.mockSyncS.EXPECT().IsBeaconSynced(gomock.Any()).Return(true).AnyTimes()
+	broker.Start(context.Background())
+	t.Cleanup(broker.Close)
+
+	inbox, et, err := broker.Register(context.Background(), instanceID1)
+	require.NoError(t, err)
+	require.NotNil(t, inbox)
+
+	_, _, err = broker.Register(context.Background(), instanceID1)
+	require.ErrorIs(t, err, errAlreadyRegistered)
+	broker.mu.RLock()
+	require.Equal(t, inbox, broker.outbox[instanceID1])
 	broker.mu.RUnlock()
+
+	broker.Unregister(context.Background(), instanceID1)
+	inbox2, et2, err := broker.Register(context.Background(), instanceID1)
+	require.NoError(t, err)
+	require.NotEqual(t, inbox, inbox2)
+	require.Equal(t, et, et2)
 }
 
 func TestBroker_Register2(t *testing.T) {
@@ -788,3 +901,87 @@ func TestBroker_Flow(t *testing.T) {
 	b.CleanOldLayers(instanceID4)
 	r.Equal(instanceID2, minDeleted(b))
 }
+
+func TestBroker_CleanOldLayers_RetentionWindow(t *testing.T) {
+	b := buildBroker(t, t.Name())
+	b.mockSyncS.EXPECT().IsSynced(gomock.Any()).Return(true).AnyTimes()
+	b.mockSyncS.EXPECT().IsBeaconSynced(gomock.Any()).Return(true).AnyTimes()
+	b.cfg.RetainLayers = 3
+
+	terminated := types.GetEffectiveGenesis().Add(1)
+	wedged := terminated.Add(1)
+	recent := wedged.Add(4)
+	for _, lid := range []types.LayerID{terminated, wedged, recent} {
+		_, _, err := b.Register(context.Background(), lid)
+		require.NoError(t, err)
+	}
+	b.mu.Lock()
+	b.pending[recent.Add(1)] = newBoundedBuffer(inboxCapacity, false)
+	require.NoError(t, b.pending[recent.Add(1)].add(types.EmptyNodeID, &Message{}))
+	b.mu.Unlock()
+
+	b.Unregister(context.Background(), terminated)
+	require.Equal(t, []types.LayerID{wedged}, b.CleanOldLayers(recent))
+
+	b.mu.RLock()
+	require.NotContains(t, b.outbox, terminated)
+	require.NotContains(t, b.outbox, wedged)
+	require.Contains(t, b.outbox, recent)
+	require.Contains(t, b.pending, recent.Add(1))
+	b.mu.RUnlock()
+	require.Equal(t, recent-1, minDeleted(b))
+	require.False(t, hasTracker(b, terminated))
+	require.False(t, hasTracker(b, wedged))
+	require.True(t, hasTracker(b, recent))
+
+	// late messages for evicted instances are dropped
+	require.ErrorIs(t, b.validateTiming(context.Background(), &Message{InnerMessage: &InnerMessage{Layer: wedged}}), errUnregistered)
+}
+
+func TestBroker_LeaderStats(t *testing.T) {
+	broker := buildBroker(t, t.Name())
+	require.Empty(t, broker.LeaderStats())
+
+	signers := make([]*signing.EdSigner, 3)
+	for i := range signers {
+		signer, err := signing.NewEdSigner()
+		require.NoError(t, err)
+		signers[i] = signer
+	}
+	s := NewSetFromValues(types.ProposalID{1})
+	// the signer with the lowest role proof leads the instance
+	leaders := []int{0, 1, 0, 2, 0, 1}
+	for _, leader := range leaders {
+		proc := generateConsensusProcess(t)
+		proc.comm.leader = broker.recordLeader
+		proc.advanceToNextRound(context.Background())
+		proc.advanceToNextRound(context.Background())
+		require.Equal(t, proposalRound, proc.currentRound())
+		proc.proposalTracker = newProposalTracker(logtest.New(t), proc.comm.mchOut, proc.eTracker, proc.cfg.ProposalSelection)
+		for i, signer := range signers {
+			proof := types.VrfSignature{1}
+			if i == leader {
+				proof = types.VrfSignature{0}
+			}
+			proc.processProposalMsg(context.Background(), buildProposalMsg(signer, s, proof))
+		}
+		proc.onRoundEnd(context.Background())
+	}
+
+	// no leader is accepted when the proposals conflict
+	proc := generateConsensusProcess(t)
+	proc.comm.leader = broker.recordLeader
+	proc.advanceToNextRound(context.Background())
+	proc.advanceToNextRound(context.Background())
+	mch := make(chan *types.MalfeasanceGossip, 1)
+	proc.proposalTracker = newProposalTracker(logtest.New(t), mch, proc.eTracker, proc.cfg.ProposalSelection)
+	proc.processProposalMsg(context.Background(), BuildProposalMsg(signers[2], s))
+	proc.processProposalMsg(context.Background(), BuildProposalMsg(signers[2], NewSetFromValues(types.ProposalID{2})))
+	proc.onRoundEnd(context.Background())
+
+	require.Equal(t, map[types.NodeID]uint64{
+		signers[0].NodeID(): 3,
+		signers[1].NodeID(): 2,
+		signers[2].NodeID(): 1,
+	}, broker.LeaderStats())
+}
@@ -0,0 +1,149 @@
+package hare
+
+import (
+	"errors"
+	"time"
+
+	"github.com/spacemeshos/go-spacemesh/common/types"
+	"github.com/spacemeshos/go-spacemesh/hare/config"
+)
+
+var (
+	errBufferFull      = errors.New("buffer is full")
+	errDuplicateSender = errors.New("sender already has a buffered message")
+)
+
+// dropPolicy decides which message is dropped when the buffer is full.
+type dropPolicy int
+
+const (
+	// dropNewest drops the message that doesn't fit.
+	dropNewest dropPolicy = iota
+	// dropOldest evicts the oldest buffered message to make room for the new one.
+	dropOldest
+)
+
+type bufferOpt func(*boundedBuffer)
+
+// withTTL evicts the messages that were buffered for longer than ttl.
+func withTTL(ttl time.Duration) bufferOpt {
+	return func(b *boundedBuffer) {
+		b.ttl = ttl
+	}
+}
+
+// withDropPolicy sets the policy applied when the buffer is full, dropNewest by default.
+func withDropPolicy(policy dropPolicy) bufferOpt {
+	return func(b *boundedBuffer) {
+		b.policy = policy
+	}
+}
+
+// bufferOptions returns the options of the buffers of early messages configured in cfg.
+func bufferOptions(cfg config.Config) []bufferOpt {
+	opts := []bufferOpt{withTTL(cfg.BufferTTL)}
+	if cfg.BufferDropPolicy == config.DropOldest {
+		opts = append(opts, withDropPolicy(dropOldest))
+	}
+	return opts
+}
+
+type bufferedMsg struct {
+	sender types.NodeID
+	msg    any
+	added  time.Time
+}
+
+// boundedBuffer holds messages that arrived before they could be handled, in the order of arrival.
+// It holds at most limit messages and, if unique is set, at most one message per sender.
+// A message that doesn't fit is dropped according to the drop policy, and if a ttl is set the messages
+// that were buffered for longer are evicted. It is not safe for concurrent use.
+type boundedBuffer struct {
+	limit   int
+	unique  bool
+	ttl     time.Duration // 0 to keep messages until they are drained
+	policy  dropPolicy
+	now     func() time.Time
+	senders map[types.NodeID]struct{} // the senders of the buffered messages, only if unique is set
+	msgs    []bufferedMsg
+}
+
+func newBoundedBuffer(limit int, unique bool, opts ...bufferOpt) *boundedBuffer {
+	b := &boundedBuffer{
+		limit:  limit,
+		unique: unique,
+		now:    time.Now,
+	}
+	if unique {
+		b.senders = map[types.NodeID]struct{}{}
+	}
+	for _, opt := range opts {
+		opt(b)
+	}
+	return b
+}
+
+// add buffers the message of the sender.
+// It returns errBufferFull or errDuplicateSender if the message is dropped.
+// With the dropOldest policy a full buffer evicts its oldest message instead.
+func (b *boundedBuffer) add(sender types.NodeID, msg any) error {
+	b.evictExpired()
+	if b.unique {
+		if _, exist := b.senders[sender]; exist {
+			return errDuplicateSender
+		}
+	}
+	if len(b.msgs) >= b.limit {
+		if b.policy != dropOldest || len(b.msgs) == 0 {
+			return errBufferFull
+		}
+		b.evict(1)
+	}
+	if b.unique {
+		b.senders[sender] = struct{}{}
+	}
+	b.msgs = append(b.msgs, bufferedMsg{sender: sender, msg: msg, added: b.now()})
+	return nil
+}
+
+// size returns the number of buffered messages.
+func (b *boundedBuffer) size() int {
+	b.evictExpired()
+	return len(b.msgs)
+}
+
+// drain returns the buffered messages in the order of arrival and empties the buffer.
+func (b *boundedBuffer) drain() []any {
+	b.evictExpired()
+	var msgs []any
+	for _, m := range b.msgs {
+		msgs = append(msgs, m.msg)
+	}
+	b.msgs = nil
+	if b.unique {
+		b.senders = map[types.NodeID]struct{}{}
+	}
+	return msgs
+}
+
+func (b *boundedBuffer) evictExpired() {
+	if b.ttl == 0 {
+		return
+	}
+	now := b.now()
+	expired := 0
+	for expired < len(b.msgs) && now.Sub(b.msgs[expired].added) >= b.ttl {
+		expired++
+	}
+	b.evict(expired)
+}
+
+// evict removes the n oldest messages.
+func (b *boundedBuffer) evict(n int) {
+	if b.unique {
+		for _, m := range b.msgs[:n] {
+			delete(b.senders, m.sender)
+		}
+	}
+	b.msgs = b.msgs[n:]
+}
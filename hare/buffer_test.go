@@ -0,0 +1,107 @@
+package hare
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/spacemeshos/go-spacemesh/common/types"
+	"github.com/spacemeshos/go-spacemesh/hare/config"
+)
+
+func TestBoundedBuffer(t *testing.T) {
+	sender1 := types.RandomNodeID()
+	sender2 := types.RandomNodeID()
+
+	t.Run("keeps order of arrival", func(t *testing.T) {
+		b := newBoundedBuffer(3, false)
+		require.NoError(t, b.add(sender1, 1))
+		require.NoError(t, b.add(sender2, 2))
+		require.NoError(t, b.add(sender1, 3))
+		require.Equal(t, 3, b.size())
+		require.Equal(t, []any{1, 2, 3}, b.drain())
+		require.Zero(t, b.size())
+		require.Empty(t, b.drain())
+	})
+	t.Run("drops when full", func(t *testing.T) {
+		b := newBoundedBuffer(2, false)
+		require.NoError(t, b.add(sender1, 1))
+		require.NoError(t, b.add(sender2, 2))
+		require.ErrorIs(t, b.add(sender2, 3), errBufferFull)
+		require.Equal(t, []any{1, 2}, b.drain())
+		require.NoError(t, b.add(sender2, 3))
+		require.Equal(t, []any{3}, b.drain())
+	})
+	t.Run("one message per sender", func(t *testing.T) {
+		b := newBoundedBuffer(3, true)
+		require.NoError(t, b.add(sender1, 1))
+		require.ErrorIs(t, b.add(sender1, 2), errDuplicateSender)
+		require.NoError(t, b.add(sender2, 3))
+		require.Equal(t, []any{1, 3}, b.drain())
+		// the sender may buffer again once drained
+		require.NoError(t, b.add(sender1, 4))
+		require.Equal(t, []any{4}, b.drain())
+	})
+	t.Run("duplicate sender is reported before full", func(t *testing.T) {
+		b := newBoundedBuffer(1, true)
+		require.NoError(t, b.add(sender1, 1))
+		require.ErrorIs(t, b.add(sender1, 2), errDuplicateSender)
+		require.ErrorIs(t, b.add(sender2, 3), errBufferFull)
+	})
+	t.Run("drop oldest", func(t *testing.T) {
+		b := newBoundedBuffer(2, true, withDropPolicy(dropOldest))
+		require.NoError(t, b.add(sender1, 1))
+		require.NoError(t, b.add(sender2, 2))
+		sender3 := types.RandomNodeID()
+		require.NoError(t, b.add(sender3, 3))
+		require.Equal(t, 2, b.size())
+		// the evicted message no longer counts for its sender
+		require.NoError(t, b.add(sender1, 4))
+		require.ErrorIs(t, b.add(sender3, 5), errDuplicateSender)
+		require.Equal(t, []any{3, 4}, b.drain())
+	})
+	t.Run("evicts expired", func(t *testing.T) {
+		b := newBoundedBuffer(2, true, withTTL(time.Minute))
+		now := time.Now()
+		b.now = func() time.Time { return now }
+		require.NoError(t, b.add(sender1, 1))
+		now = now.Add(30 * time.Second)
+		require.NoError(t, b.add(sender2, 2))
+		require.ErrorIs(t, b.add(types.RandomNodeID(), 3), errBufferFull)
+
+		now = now.Add(30 * time.Second)
+		require.Equal(t, 1, b.size())
+		require.NoError(t, b.add(sender1, 4))
+		now = now.Add(time.Minute)
+		require.Empty(t, b.drain())
+	})
+	t.Run("senders are tracked only if unique", func(t *testing.T) {
+		b := newBoundedBuffer(3, false)
+		require.NoError(t, b.add(sender1, 1))
+		require.NoError(t, b.add(sender1, 2))
+		require.Empty(t, b.senders)
+	})
+}
+
+func TestBufferOptions(t *testing.T) {
+	sender1 := types.RandomNodeID()
+	sender2 := types.RandomNodeID()
+
+	cfg := config.DefaultConfig()
+	b := newBoundedBuffer(1, false, bufferOptions(cfg)...)
+	require.NoError(t, b.add(sender1, 1))
+	require.ErrorIs(t, b.add(sender2, 2), errBufferFull)
+	require.Equal(t, []any{1}, b.drain())
+
+	cfg.BufferTTL = time.Minute
+	cfg.BufferDropPolicy = config.DropOldest
+	b = newBoundedBuffer(1, false, bufferOptions(cfg)...)
+	now := time.Now()
+	b.now = func() time.Time { return now }
+	require.NoError(t, b.add(sender1, 1))
+	require.NoError(t, b.add(sender2, 2))
+	require.Equal(t, 1, b.size())
+	now = now.Add(time.Minute)
+	require.Empty(t, b.drain())
+}
@@ -1,6 +1,40 @@
 package config
 
-import "time"
+import (
+	"fmt"
+	"time"
+)
+
+// ProposalSelection is the rule used to select a single proposal among the valid proposals of a round.
+// All honest nodes must use the same rule, otherwise they may commit to different proposals.
+type ProposalSelection string
+
+const (
+	// LowestProof selects the proposal with the lowest role proof.
+	LowestProof ProposalSelection = "lowest-proof"
+	// LowestID selects the proposal of the proposer with the lowest id.
+	LowestID ProposalSelection = "lowest-id"
+	// HighestWeight selects the proposal with the highest eligibility count.
+	HighestWeight ProposalSelection = "highest-weight"
+)
+
+// BufferDropPolicy is the rule used to select the message that is dropped when a buffer of early messages is full.
+type BufferDropPolicy string
+
+const (
+	// DropNewest drops the message that doesn't fit.
+	DropNewest BufferDropPolicy = "drop-newest"
+	// DropOldest evicts the oldest buffered message to make room for the new one.
+	DropOldest BufferDropPolicy = "drop-oldest"
+)
+
+// names of the rounds with a threshold that can be overridden in Config.RoundThresholds.
+const (
+	ThresholdPreRound = "preround"
+	ThresholdStatus   = "status"
+	ThresholdCommit   = "commit"
+	ThresholdNotify   = "notify"
+)
 
 // Config is the configuration of the Hare.
 type Config struct {
@@ -10,6 +44,28 @@ type Config struct {
 	ExpectedLeaders int           `mapstructure:"hare-exp-leaders"`      // the expected number of leaders
 	LimitIterations int           `mapstructure:"hare-limit-iterations"` // limit on number of iterations
 	LimitConcurrent int           `mapstructure:"hare-limit-concurrent"` // limit number of concurrent CPs
+	RetainLayers    uint32        `mapstructure:"hare-retain-layers"`    // number of layers the broker retains the state of an instance
+	MinParticipants int           `mapstructure:"hare-min-participants"` // minimum number of active participants required to start a CP, 0 to disable
+	StartTimeout    time.Duration `mapstructure:"hare-start-timeout"`    // how long a CP waits for the minimum number of participants
+	Deadline        time.Duration `mapstructure:"hare-deadline"`         // a CP that did not terminate by the deadline is aborted, 0 to disable
+
+	ProposalSelection ProposalSelection `mapstructure:"hare-proposal-selection"` // rule to select among competing proposals, lowest-proof if empty
+	// RoundThresholds overrides the eligibility count a round requires, keyed by the round name.
+	// Rounds without an override require a majority of the committee.
+	RoundThresholds map[string]int `mapstructure:"hare-round-thresholds"`
+	// ValueProvenance records the first sender of every value a CP receives, for debugging adopted sets.
+	ValueProvenance bool `mapstructure:"hare-value-provenance"`
+	// AuditLogSize is the number of threshold-crossing events a CP retains for post-incident analysis, 0 to disable.
+	// When the log is full the oldest event is dropped.
+	AuditLogSize int `mapstructure:"hare-audit-log-size"`
+	// SetSize is the expected number of values in a proposed set, 0 to disable the check.
+	// Status and proposal messages with a set further than SetSizeTolerance from SetSize are rejected.
+	SetSize          int `mapstructure:"hare-set-size"`
+	SetSizeTolerance int `mapstructure:"hare-set-size-tolerance"`
+	// BufferTTL is how long a message that arrived before it can be handled is buffered, 0 to keep it until it is handled.
+	BufferTTL time.Duration `mapstructure:"hare-buffer-ttl"`
+	// BufferDropPolicy selects the message dropped when a buffer of early messages is full, drop-newest if empty.
+	BufferDropPolicy BufferDropPolicy `mapstructure:"hare-buffer-drop-policy"`
 
 	Hdist uint32
 }
@@ -23,6 +79,75 @@ func DefaultConfig() Config {
 		ExpectedLeaders: 5,
 		LimitIterations: 5,
 		LimitConcurrent: 5,
+		RetainLayers:    10,
+		StartTimeout:    10 * time.Second,
 		Hdist:           20,
+
+		ProposalSelection: LowestProof,
+		BufferDropPolicy:  DropNewest,
+	}
+}
+
+// Validate returns an error naming the first field of the config with an invalid value.
+func (c *Config) Validate() error {
+	switch {
+	case c.N <= 0:
+		return fmt.Errorf("hare-committee-size must be positive: %d", c.N)
+	case c.ExpectedLeaders <= 0:
+		return fmt.Errorf("hare-exp-leaders must be positive: %d", c.ExpectedLeaders)
+	case c.ExpectedLeaders > c.N:
+		return fmt.Errorf("hare-exp-leaders (%d) must not exceed hare-committee-size (%d)", c.ExpectedLeaders, c.N)
+	case c.RoundDuration <= 0:
+		return fmt.Errorf("hare-round-duration must be positive: %s", c.RoundDuration)
+	case c.WakeupDelta < 0:
+		return fmt.Errorf("hare-wakeup-delta must not be negative: %s", c.WakeupDelta)
+	case c.LimitIterations <= 0:
+		return fmt.Errorf("hare-limit-iterations must be positive: %d", c.LimitIterations)
+	case c.LimitConcurrent <= 0:
+		return fmt.Errorf("hare-limit-concurrent must be positive: %d", c.LimitConcurrent)
+	case c.MinParticipants < 0:
+		return fmt.Errorf("hare-min-participants must not be negative: %d", c.MinParticipants)
+	case c.MinParticipants > 0 && c.StartTimeout <= 0:
+		return fmt.Errorf("hare-start-timeout must be positive when hare-min-participants is set: %s", c.StartTimeout)
+	case c.Deadline < 0:
+		return fmt.Errorf("hare-deadline must not be negative: %s", c.Deadline)
+	case c.AuditLogSize < 0:
+		return fmt.Errorf("hare-audit-log-size must not be negative: %d", c.AuditLogSize)
+	case c.SetSize < 0:
+		return fmt.Errorf("hare-set-size must not be negative: %d", c.SetSize)
+	case c.SetSizeTolerance < 0:
+		return fmt.Errorf("hare-set-size-tolerance must not be negative: %d", c.SetSizeTolerance)
+	case c.BufferTTL < 0:
+		return fmt.Errorf("hare-buffer-ttl must not be negative: %s", c.BufferTTL)
+	}
+	switch c.ProposalSelection {
+	case "", LowestProof, LowestID, HighestWeight:
+	default:
+		return fmt.Errorf("hare-proposal-selection is unknown: %q", c.ProposalSelection)
+	}
+	switch c.BufferDropPolicy {
+	case "", DropNewest, DropOldest:
+	default:
+		return fmt.Errorf("hare-buffer-drop-policy is unknown: %q", c.BufferDropPolicy)
+	}
+	for round, threshold := range c.RoundThresholds {
+		switch round {
+		case ThresholdPreRound, ThresholdStatus, ThresholdCommit, ThresholdNotify:
+		default:
+			return fmt.Errorf("hare-round-thresholds has an unknown round: %q", round)
+		}
+		// a threshold at or below half of the committee could be met by the faulty participants alone
+		if threshold <= c.N/2 || threshold > c.N {
+			return fmt.Errorf("hare-round-thresholds for %s must be in (%d, %d]: %d", round, c.N/2, c.N, threshold)
+		}
+	}
+	return nil
+}
+
+// Threshold returns the eligibility count required in the round.
+func (c *Config) Threshold(round string) int {
+	if threshold, ok := c.RoundThresholds[round]; ok {
+		return threshold
 	}
+	return c.N/2 + 1
 }
@@ -0,0 +1,58 @@
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidate(t *testing.T) {
+	cfg := DefaultConfig()
+	require.NoError(t, cfg.Validate())
+
+	for _, tc := range []struct {
+		desc   string
+		modify func(*Config)
+		field  string
+	}{
+		{"zero committee", func(c *Config) { c.N = 0 }, "hare-committee-size"},
+		{"zero leaders", func(c *Config) { c.ExpectedLeaders = 0 }, "hare-exp-leaders"},
+		{"leaders exceed committee", func(c *Config) { c.ExpectedLeaders = c.N + 1 }, "hare-exp-leaders"},
+		{"zero round duration", func(c *Config) { c.RoundDuration = 0 }, "hare-round-duration"},
+		{"negative wakeup delta", func(c *Config) { c.WakeupDelta = -time.Second }, "hare-wakeup-delta"},
+		{"zero iterations", func(c *Config) { c.LimitIterations = 0 }, "hare-limit-iterations"},
+		{"zero concurrent", func(c *Config) { c.LimitConcurrent = 0 }, "hare-limit-concurrent"},
+		{"negative participants", func(c *Config) { c.MinParticipants = -1 }, "hare-min-participants"},
+		{"participants without timeout", func(c *Config) {
+			c.MinParticipants = 3
+			c.StartTimeout = 0
+		}, "hare-start-timeout"},
+		{"negative deadline", func(c *Config) { c.Deadline = -time.Second }, "hare-deadline"},
+		{"negative audit log size", func(c *Config) { c.AuditLogSize = -1 }, "hare-audit-log-size"},
+		{"negative set size", func(c *Config) { c.SetSize = -1 }, "hare-set-size"},
+		{"negative set size tolerance", func(c *Config) { c.SetSizeTolerance = -1 }, "hare-set-size-tolerance"},
+		{"negative buffer ttl", func(c *Config) { c.BufferTTL = -time.Second }, "hare-buffer-ttl"},
+		{"unknown buffer drop policy", func(c *Config) { c.BufferDropPolicy = "drop-random" }, "hare-buffer-drop-policy"},
+		{"unknown proposal selection", func(c *Config) { c.ProposalSelection = "random" }, "hare-proposal-selection"},
+		{"unknown round threshold", func(c *Config) { c.RoundThresholds = map[string]int{"proposal": 8} }, "hare-round-thresholds"},
+		{"unsafe round threshold", func(c *Config) { c.RoundThresholds = map[string]int{ThresholdCommit: c.N / 2} }, "hare-round-thresholds"},
+		{"unreachable round threshold", func(c *Config) { c.RoundThresholds = map[string]int{ThresholdNotify: c.N + 1} }, "hare-round-thresholds"},
+	} {
+		tc := tc
+		t.Run(tc.desc, func(t *testing.T) {
+			cfg := DefaultConfig()
+			tc.modify(&cfg)
+			require.ErrorContains(t, cfg.Validate(), tc.field)
+		})
+	}
+}
+
+func TestThreshold(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.N = 10
+	cfg.RoundThresholds = map[string]int{ThresholdCommit: 8}
+	require.NoError(t, cfg.Validate())
+	require.Equal(t, 8, cfg.Threshold(ThresholdCommit))
+	require.Equal(t, 6, cfg.Threshold(ThresholdNotify))
+}
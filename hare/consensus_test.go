@@ -162,6 +162,7 @@ type testCP struct {
 	cp     *consensusProcess
 	broker *Broker
 	mch    chan *types.MalfeasanceGossip
+	output chan report
 }
 
 func createConsensusProcess(
@@ -210,10 +211,130 @@ func createConsensusProcess(
 		network,
 		comm,
 		truer{},
+		nil,
 		newRoundClockFromCfg(logtest.New(tb), cfg),
 		logtest.New(tb).WithName(sig.PublicKey().ShortString()),
 	)
-	return &testCP{cp: proc, broker: broker.Broker, mch: mch}
+	return &testCP{cp: proc, broker: broker.Broker, mch: mch, output: output}
+}
+
+// runConsensus runs n honest consensus processes over a mocked network, one broker per node,
+// until all of them terminate. it returns the set decided by each node.
+func runConsensus(tb testing.TB, n int, cfg config.Config, initial []*Set, timeout time.Duration) ([]*Set, error) {
+	tb.Helper()
+	return runConsensusWith(tb, n, cfg, initial, timeout, nil)
+}
+
+// runConsensusWith is runConsensus where the network of the i-th node is wrapped with wrap if not nil.
+func runConsensusWith(
+	tb testing.TB,
+	n int,
+	cfg config.Config,
+	initial []*Set,
+	timeout time.Duration,
+	wrap func(int, pubsub.PublishSubsciber) pubsub.PublishSubsciber,
+) ([]*Set, error) {
+	tb.Helper()
+	require.Len(tb, initial, n)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	mesh, err := mocknet.FullMeshLinked(n)
+	require.NoError(tb, err)
+
+	oracle := eligibility.New(logtest.New(tb))
+	tcps := make([]*testCP, 0, n)
+	pss := make([]*pubsub.PubSub, 0, n)
+	for i := 0; i < n; i++ {
+		ps, err := pubsub.New(ctx, logtest.New(tb), mesh.Hosts()[i], pubsub.DefaultConfig())
+		require.NoError(tb, err)
+		sig, err := signing.NewEdSigner()
+		require.NoError(tb, err)
+		var network pubsub.PublishSubsciber = ps
+		if wrap != nil {
+			network = wrap(i, ps)
+		}
+		tcps = append(tcps, createConsensusProcess(tb, ctx, sig, true, cfg, oracle, network, initial[i], instanceID1))
+		pss = append(pss, ps)
+	}
+	require.NoError(tb, mesh.ConnectAllButSelf())
+	// messages published before the peers joined the topic are lost
+	require.Eventually(tb, func() bool {
+		for _, ps := range pss {
+			if len(ps.ProtocolPeers(pubsub.HareProtocol)) < n-1 {
+				return false
+			}
+		}
+		return true
+	}, 10*time.Second, 10*time.Millisecond)
+	defer func() {
+		for _, tcp := range tcps {
+			tcp.cp.terminate()
+			tcp.cp.Stop()
+			tcp.broker.Close()
+		}
+	}()
+	for _, tcp := range tcps {
+		tcp.cp.Start()
+	}
+
+	timer := time.After(timeout)
+	outputs := make([]*Set, 0, n)
+	for i, tcp := range tcps {
+		select {
+		case out := <-tcp.output:
+			if !out.completed {
+				return nil, fmt.Errorf("node %d did not complete", i)
+			}
+			outputs = append(outputs, out.set)
+		case <-timer:
+			return nil, fmt.Errorf("timed out waiting for node %d", i)
+		}
+	}
+	return outputs, nil
+}
+
+func TestConsensus_Agreement(t *testing.T) {
+	const totalNodes = 7
+	cfg := config.Config{N: totalNodes, RoundDuration: time.Second, ExpectedLeaders: 5, LimitIterations: 1000, Hdist: 20}
+
+	set1 := NewSetFromValues(types.ProposalID{1}, types.ProposalID{2})
+	set2 := NewSetFromValues(types.ProposalID{1}, types.ProposalID{2}, types.ProposalID{3})
+	initial := make([]*Set, totalNodes)
+	for i := range initial {
+		initial[i] = set1
+		if i%2 == 1 {
+			initial[i] = set2
+		}
+	}
+
+	outputs, err := runConsensus(t, totalNodes, cfg, initial, 30*time.Second)
+	require.NoError(t, err)
+	require.Len(t, outputs, totalNodes)
+	for _, out := range outputs {
+		require.True(t, outputs[0].Equals(out), "expected %v, got %v", outputs[0], out)
+	}
+	require.True(t, set1.IsSubSetOf(outputs[0]))
+	require.True(t, outputs[0].IsSubSetOf(set2))
+}
+
+func TestConsensus_EmptySet(t *testing.T) {
+	const totalNodes = 7
+	cfg := config.Config{N: totalNodes, RoundDuration: time.Second, ExpectedLeaders: 5, LimitIterations: 1000, Hdist: 20}
+
+	initial := make([]*Set, totalNodes)
+	for i := range initial {
+		initial[i] = NewDefaultEmptySet()
+	}
+
+	// agreeing on nothing is a decision, not a failure
+	outputs, err := runConsensus(t, totalNodes, cfg, initial, 30*time.Second)
+	require.NoError(t, err)
+	require.Len(t, outputs, totalNodes)
+	for _, out := range outputs {
+		require.NotNil(t, out)
+		require.Zero(t, out.Size())
+	}
 }
 
 // Test - runs a single CP for more than one iteration.
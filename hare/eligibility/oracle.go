@@ -476,6 +476,15 @@ func (o *Oracle) IsIdentityActiveOnConsensusView(ctx context.Context, edID types
 	return exist, nil
 }
 
+// ActiveCount returns the number of active identities on the consensus view derived from the specified layer.
+func (o *Oracle) ActiveCount(ctx context.Context, layer types.LayerID) (int, error) {
+	actives, err := o.actives(ctx, layer)
+	if err != nil {
+		return 0, err
+	}
+	return len(actives.set), nil
+}
+
 func (o *Oracle) UpdateActiveSet(epoch types.EpochID, activeSet []types.ATXID) {
 	o.Log.With().Info("received activeset update",
 		epoch,
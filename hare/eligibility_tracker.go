@@ -1,6 +1,8 @@
 package hare
 
 import (
+	"bytes"
+	"sort"
 	"sync"
 
 	"github.com/spacemeshos/go-spacemesh/common/types"
@@ -66,3 +68,40 @@ func (et *EligibilityTracker) Dishonest(nodeID types.NodeID, round uint32) bool
 	}
 	return false
 }
+
+// Participant is an identity that sent messages to a consensus process.
+type Participant struct {
+	ID      types.NodeID
+	Rounds  []uint32 // the rounds the identity sent messages in, starting with the preround
+	Honest  bool     // false if the identity is known malicious in any of the rounds
+	Current bool     // the identity sent a message in the current round
+}
+
+// Participants returns the tracked identities ordered by id.
+func (et *EligibilityTracker) Participants(current uint32) []Participant {
+	et.mu.RLock()
+	defer et.mu.RUnlock()
+	byID := make(map[types.NodeID]*Participant)
+	for round, nodes := range et.nodesByRound {
+		for id, cred := range nodes {
+			p, ok := byID[id]
+			if !ok {
+				p = &Participant{ID: id, Honest: true}
+				byID[id] = p
+			}
+			p.Rounds = append(p.Rounds, round)
+			p.Honest = p.Honest && cred.Honest
+			p.Current = p.Current || round == current
+		}
+	}
+	participants := make([]Participant, 0, len(byID))
+	for _, p := range byID {
+		// the preround is MaxUint32, it wraps around to sort first
+		sort.Slice(p.Rounds, func(i, j int) bool { return p.Rounds[i]+1 < p.Rounds[j]+1 })
+		participants = append(participants, *p)
+	}
+	sort.Slice(participants, func(i, j int) bool {
+		return bytes.Compare(participants[i].ID.Bytes(), participants[j].ID.Bytes()) < 0
+	})
+	return participants
+}
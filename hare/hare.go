@@ -8,6 +8,7 @@ import (
 	"sync"
 	"time"
 
+	"golang.org/x/exp/maps"
 	"golang.org/x/sync/errgroup"
 
 	"github.com/spacemeshos/go-spacemesh/codec"
@@ -43,6 +44,13 @@ type Consensus interface {
 	ID() types.LayerID
 	Start()
 	Stop()
+	// Shutdown lets the current round end before the consensus process terminates, or until ctx is done.
+	Shutdown(ctx context.Context) error
+
+	Round() (uint32, MessageType)
+	Participants() []Participant
+	Provenance() map[types.ProposalID]types.NodeID
+	AuditLog() []AuditEntry
 }
 
 // RoundClock is a timer interface.
@@ -94,6 +102,14 @@ func withMesh(m mesh) Opt {
 	}
 }
 
+// WithValidValue configures the application-level validity of the values proposed in consensus.
+// Consensus processes never adopt values rejected by valid.
+func WithValidValue(valid func(types.ProposalID) bool) Opt {
+	return func(h *Hare) {
+		h.validValue = valid
+	}
+}
+
 // Hare is the orchestrator that starts new consensus processes and collects their output.
 type Hare struct {
 	log.Log
@@ -123,7 +139,8 @@ type Hare struct {
 	outputs    map[types.LayerID][]types.ProposalID
 	cps        map[types.LayerID]Consensus
 
-	factory consensusFactory
+	factory    consensusFactory
+	validValue func(types.ProposalID) bool
 
 	nodeID      types.NodeID
 	sigVerifier malfeasance.SigVerifier
@@ -186,7 +203,7 @@ func New(
 	h.outputs = make(map[types.LayerID][]types.ProposalID, h.config.Hdist) // we keep results about LayerBuffer past layers
 	h.cps = make(map[types.LayerID]Consensus, h.config.LimitConcurrent)
 	h.factory = func(ctx context.Context, conf config.Config, instanceId types.LayerID, s *Set, oracle Rolacle, et *EligibilityTracker, signing *signing.EdSigner, p2p pubsub.Publisher, comm communication, clock RoundClock) Consensus {
-		return newConsensusProcess(ctx, conf, instanceId, s, oracle, stateQ, signing, edVerifier, et, nid, p2p, comm, ev, clock, logger)
+		return newConsensusProcess(ctx, conf, instanceId, s, oracle, stateQ, signing, edVerifier, et, nid, p2p, comm, ev, h.validValue, clock, logger)
 	}
 
 	h.nodeID = nid
@@ -214,6 +231,52 @@ func (h *Hare) HandleEligibility(ctx context.Context, emsg *types.HareEligibilit
 	h.broker.HandleEligibility(ctx, emsg)
 }
 
+// LeaderStats returns how many times each identity was the accepted leader, see Broker.LeaderStats.
+func (h *Hare) LeaderStats() map[types.NodeID]uint64 {
+	return h.broker.LeaderStats()
+}
+
+// Round returns the round counter (K) and the type of the current round of the consensus process
+// running for the layer, false if none is running.
+func (h *Hare) Round(layer types.LayerID) (uint32, MessageType, bool) {
+	cp := h.getCP(layer)
+	if cp == nil {
+		return 0, 0, false
+	}
+	k, typ := cp.Round()
+	return k, typ, true
+}
+
+// Participants returns the identities that sent messages to the consensus process running for the layer,
+// nil if none is running.
+func (h *Hare) Participants(layer types.LayerID) []Participant {
+	cp := h.getCP(layer)
+	if cp == nil {
+		return nil
+	}
+	return cp.Participants()
+}
+
+// Provenance returns the identity that first carried each value in the consensus process running for
+// the layer. It returns nil if none is running or unless hare-value-provenance is set.
+func (h *Hare) Provenance(layer types.LayerID) map[types.ProposalID]types.NodeID {
+	cp := h.getCP(layer)
+	if cp == nil {
+		return nil
+	}
+	return cp.Provenance()
+}
+
+// AuditLog returns the most recent threshold-crossing events of the consensus process running for
+// the layer, oldest first. It returns nil if none is running or unless hare-audit-log-size is set.
+func (h *Hare) AuditLog(layer types.LayerID) []AuditEntry {
+	cp := h.getCP(layer)
+	if cp == nil {
+		return nil
+	}
+	return cp.AuditLog()
+}
+
 func (h *Hare) getLastLayer() types.LayerID {
 	h.mu.Lock()
 	defer h.mu.Unlock()
@@ -274,8 +337,13 @@ func (h *Hare) collectOutput(ctx context.Context, output report) error {
 	var pids []types.ProposalID
 	if output.completed {
 		consensusOkCnt.Inc()
-		h.WithContext(ctx).With().Info("hare terminated with success", layerID, log.Int("num_proposals", output.set.Size()))
 		set := output.set
+		if set.Size() == 0 {
+			// agreeing on no proposals is a decision, the layer is empty
+			h.WithContext(ctx).With().Info("hare terminated with success on empty set", layerID)
+		} else {
+			h.WithContext(ctx).With().Info("hare terminated with success", layerID, log.Int("num_proposals", set.Size()))
+		}
 		postNumProposals.Add(float64(set.Size()))
 		pids = set.ToSlice()
 		select {
@@ -387,6 +455,7 @@ func (h *Hare) onTick(ctx context.Context, lid types.LayerID) (bool, error) {
 		mchOut: h.mchMalfeasance,
 		report: h.outputChan,
 		wc:     h.wcChan,
+		leader: h.broker.recordLeader,
 	}
 	props := goodProposals(ctx, h.Log, h.msh, h.nodeID, lid, beacon)
 	preNumProposals.Add(float64(len(props)))
@@ -440,6 +509,21 @@ func (h *Hare) removeCP(ctx context.Context, lid types.LayerID) {
 	processesGauge.Set(float64(len(h.cps)))
 }
 
+// cleanOldLayers frees the broker state of the layers that are no longer needed and terminates
+// the consensus processes of the layers the broker evicted, without waiting for the end of their round.
+func (h *Hare) cleanOldLayers(ctx context.Context, current types.LayerID) {
+	for _, lid := range h.broker.CleanOldLayers(current) {
+		cp := h.getCP(lid)
+		if cp == nil {
+			continue
+		}
+		aborted, cancel := context.WithCancel(ctx)
+		cancel()
+		_ = cp.Shutdown(aborted)
+		h.removeCP(ctx, lid)
+	}
+}
+
 // goodProposals finds the "good proposals" for the specified layer. a proposal is good if
 // it has the same beacon value as the node's beacon value.
 // any error encountered will be ignored and an empty set is returned.
@@ -540,6 +624,8 @@ var (
 	errNoResult = errors.New("no result for the requested layer")
 )
 
+// getResult returns the proposals hare decided on for the layer.
+// The result is empty if hare agreed on no proposals, and nil if it failed to decide.
 func (h *Hare) getResult(lid types.LayerID) ([]types.ProposalID, error) {
 	if h.outOfBufferRange(lid) {
 		return nil, errTooOld
@@ -604,7 +690,7 @@ func (h *Hare) tickLoop(ctx context.Context) {
 			if err != nil && !errors.Is(err, context.Canceled) {
 				h.With().Warning("hare failed", log.Context(ctx), layer, log.Err(err))
 			}
-			h.broker.CleanOldLayers(layer)
+			h.cleanOldLayers(ctx, layer)
 		case <-h.ctx.Done():
 			return
 		}
@@ -697,6 +783,26 @@ func (h *Hare) Start(ctx context.Context) error {
 	return nil
 }
 
+// Shutdown lets the running consensus processes end their current round, or until ctx is done,
+// so that their last messages still count towards the quorum. Then it closes hare.
+// It returns ctx.Err() if a consensus process was terminated before the end of its round.
+func (h *Hare) Shutdown(ctx context.Context) error {
+	h.mu.Lock()
+	cps := maps.Values(h.cps)
+	h.mu.Unlock()
+
+	var eg errgroup.Group
+	for _, cp := range cps {
+		cp := cp
+		eg.Go(func() error {
+			return cp.Shutdown(ctx)
+		})
+	}
+	err := eg.Wait()
+	h.Close()
+	return err
+}
+
 // Close sends a termination signal to hare goroutines and waits for their termination.
 func (h *Hare) Close() {
 	h.cancel()
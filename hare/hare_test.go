@@ -27,11 +27,12 @@ import (
 )
 
 type mockConsensusProcess struct {
-	started chan struct{}
-	t       chan report
-	w       chan wcReport
-	id      types.LayerID
-	set     *Set
+	started  chan struct{}
+	t        chan report
+	w        chan wcReport
+	id       types.LayerID
+	set      *Set
+	shutdown bool
 }
 
 func (mcp *mockConsensusProcess) Start() {
@@ -42,6 +43,27 @@ func (mcp *mockConsensusProcess) Start() {
 
 func (mcp *mockConsensusProcess) Stop() {}
 
+func (mcp *mockConsensusProcess) Shutdown(context.Context) error {
+	mcp.shutdown = true
+	return nil
+}
+
+func (mcp *mockConsensusProcess) Round() (uint32, MessageType) {
+	return preRound, pre
+}
+
+func (mcp *mockConsensusProcess) Participants() []Participant {
+	return nil
+}
+
+func (mcp *mockConsensusProcess) Provenance() map[types.ProposalID]types.NodeID {
+	return nil
+}
+
+func (mcp *mockConsensusProcess) AuditLog() []AuditEntry {
+	return nil
+}
+
 func (mcp *mockConsensusProcess) ID() types.LayerID {
 	return mcp.id
 }
@@ -146,6 +168,77 @@ func TestHare_Start(t *testing.T) {
 	h.Close()
 }
 
+func TestHare_Shutdown(t *testing.T) {
+	h := createTestHare(t, newMockMesh(t), config.DefaultConfig(), newMockClock(), noopPubSub(t), t.Name())
+	require.NoError(t, h.Start(context.Background()))
+	var cps []*mockConsensusProcess
+	for lid := types.LayerID(1); lid <= 3; lid++ {
+		cp := newMockConsensusProcess(h.config, lid, nil, nil, nil, nil, nil, nil, nil)
+		h.addCP(context.Background(), cp)
+		cps = append(cps, cp)
+	}
+	require.NoError(t, h.Shutdown(context.Background()))
+	for _, cp := range cps {
+		require.True(t, cp.shutdown)
+	}
+	require.True(t, h.isClosed())
+}
+
+func TestHare_CleanOldLayersTerminatesEvicted(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.RetainLayers = 3
+	h := createTestHare(t, newMockMesh(t), cfg, newMockClock(), noopPubSub(t), t.Name())
+
+	wedged := types.GetEffectiveGenesis().Add(1)
+	recent := wedged.Add(4)
+	cps := map[types.LayerID]*mockConsensusProcess{}
+	for _, lid := range []types.LayerID{wedged, recent} {
+		_, _, err := h.broker.Register(context.Background(), lid)
+		require.NoError(t, err)
+		cps[lid] = newMockConsensusProcess(h.config, lid, nil, nil, nil, nil, nil, nil, nil)
+		h.addCP(context.Background(), cps[lid])
+	}
+
+	h.cleanOldLayers(context.Background(), recent)
+	require.True(t, cps[wedged].shutdown)
+	require.Nil(t, h.getCP(wedged))
+	require.False(t, cps[recent].shutdown)
+	require.NotNil(t, h.getCP(recent))
+}
+
+func TestHare_ConsensusState(t *testing.T) {
+	cfg := config.Config{N: 10, RoundDuration: 2 * time.Second, ExpectedLeaders: 5, LimitIterations: 1000, LimitConcurrent: 1000, Hdist: 20, ValueProvenance: true, AuditLogSize: 1}
+	h := createTestHare(t, newMockMesh(t), cfg, newMockClock(), noopPubSub(t), t.Name())
+
+	_, _, ok := h.Round(instanceID1)
+	require.False(t, ok)
+	require.Nil(t, h.Participants(instanceID1))
+	require.Nil(t, h.Provenance(instanceID1))
+	require.Nil(t, h.AuditLog(instanceID1))
+
+	proc := generateConsensusProcessWithConfig(t, cfg, make(chan any, 10))
+	t.Cleanup(proc.terminate)
+	h.addCP(context.Background(), proc)
+	proc.setRound(commitRound)
+	signer, err := signing.NewEdSigner()
+	require.NoError(t, err)
+	msg := BuildPreRoundMsg(signer, NewSetFromValues(types.ProposalID{1}), types.EmptyVrfSignature)
+	proc.eTracker.Track(msg.SmesherID, msg.Round, msg.Eligibility.Count, true)
+	proc.recordProvenance(msg)
+	entry := AuditEntry{Round: commitRound, Type: commit, Senders: []types.NodeID{msg.SmesherID}}
+	proc.audit(context.Background(), entry)
+
+	k, typ, ok := h.Round(instanceID1)
+	require.True(t, ok)
+	require.Equal(t, commitRound, k)
+	require.Equal(t, commit, typ)
+	participants := h.Participants(instanceID1)
+	require.Len(t, participants, 1)
+	require.Equal(t, msg.SmesherID, participants[0].ID)
+	require.Equal(t, map[types.ProposalID]types.NodeID{{1}: msg.SmesherID}, h.Provenance(instanceID1))
+	require.Equal(t, []AuditEntry{entry}, h.AuditLog(instanceID1))
+}
+
 func TestHare_collectOutputAndGetResult(t *testing.T) {
 	h := createTestHare(t, newMockMesh(t), config.DefaultConfig(), newMockClock(), noopPubSub(t), t.Name())
 
@@ -170,6 +263,27 @@ func TestHare_collectOutputAndGetResult(t *testing.T) {
 	require.Empty(t, res)
 }
 
+func TestHare_collectOutputEmptySet(t *testing.T) {
+	h := createTestHare(t, newMockMesh(t), config.DefaultConfig(), newMockClock(), noopPubSub(t), t.Name())
+
+	decided := types.LayerID(10)
+	require.NoError(t, h.collectOutput(context.Background(), report{id: decided, set: NewDefaultEmptySet(), completed: true}))
+	lo := <-h.blockGenCh
+	require.Equal(t, decided, lo.Layer)
+	require.Empty(t, lo.Proposals)
+	res, err := h.getResult(decided)
+	require.NoError(t, err)
+	require.NotNil(t, res)
+	require.Empty(t, res)
+
+	failed := decided.Add(1)
+	require.NoError(t, h.collectOutput(context.Background(), report{id: failed, set: NewDefaultEmptySet(), completed: false}))
+	require.Empty(t, h.blockGenCh)
+	res, err = h.getResult(failed)
+	require.NoError(t, err)
+	require.Nil(t, res)
+}
+
 func TestHare_collectOutputGetResult_TerminateTooLate(t *testing.T) {
 	h := createTestHare(t, newMockMesh(t), config.DefaultConfig(), newMockClock(), noopPubSub(t), t.Name())
 
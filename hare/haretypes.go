@@ -57,6 +57,23 @@ type Set struct {
 	mu     sync.RWMutex
 	values map[types.ProposalID]struct{}
 	sid    types.Hash32
+	less   func(a, b types.ProposalID) bool // canonical order of the values, byte order if nil
+}
+
+// SetOpt configures a Set.
+type SetOpt func(*Set)
+
+// WithOrder configures the canonical order of the values of the set, used by ToSlice.
+// The ID of a set is computed in byte order, so equal sets have the same ID whatever their order.
+// Sets derived from the set (Clone, Intersection, Union, Complement) keep its order.
+func WithOrder(less func(a, b types.ProposalID) bool) SetOpt {
+	return func(s *Set) {
+		s.less = less
+	}
+}
+
+func byteOrder(a, b types.ProposalID) bool {
+	return bytes.Compare(a.Bytes(), b.Bytes()) == -1
 }
 
 // NewDefaultEmptySet creates an empty set with the default size.
@@ -65,8 +82,12 @@ func NewDefaultEmptySet() *Set {
 }
 
 // NewEmptySet creates an empty set with the provided size.
-func NewEmptySet(size int) *Set {
-	return &Set{values: make(map[types.ProposalID]struct{}, size)}
+func NewEmptySet(size int, opts ...SetOpt) *Set {
+	s := &Set{values: make(map[types.ProposalID]struct{}, size)}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
 }
 
 // NewSetFromValues creates a set of the provided values.
@@ -81,8 +102,8 @@ func NewSetFromValues(values ...types.ProposalID) *Set {
 
 // NewSet creates a set from the provided array of values.
 // Note: duplicated values are ignored.
-func NewSet(data []types.ProposalID) *Set {
-	s := &Set{values: make(map[types.ProposalID]struct{}, len(data))}
+func NewSet(data []types.ProposalID, opts ...SetOpt) *Set {
+	s := NewEmptySet(len(data), opts...)
 	for _, v := range data {
 		s.values[v] = struct{}{}
 	}
@@ -94,7 +115,7 @@ func (s *Set) Clone() *Set {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 
-	clone := NewEmptySet(len(s.values))
+	clone := NewEmptySet(len(s.values), WithOrder(s.less))
 	for v := range s.values {
 		clone.values[v] = struct{}{}
 	}
@@ -161,12 +182,14 @@ func (s *Set) Equals(g *Set) bool {
 }
 
 // ToSlice returns the array representation of the set.
+// The values are sorted in the canonical order of the set (by their bytes unless configured with WithOrder),
+// so equal sets with the same order always yield identical slices.
 func (s *Set) ToSlice() []types.ProposalID {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 
 	// order keys
-	return s.sortedLocked()
+	return s.sortedLocked(s.less)
 }
 
 // ID returns the ObjectID of the set.
@@ -179,7 +202,7 @@ func (s *Set) ID() types.Hash32 {
 	}
 
 	// order keys
-	keys := s.sortedLocked()
+	keys := s.sortedLocked(byteOrder)
 
 	// calc
 	h := hash.New()
@@ -192,9 +215,12 @@ func (s *Set) ID() types.Hash32 {
 	return s.sid
 }
 
-func (s *Set) sortedLocked() []types.ProposalID {
+func (s *Set) sortedLocked(less func(a, b types.ProposalID) bool) []types.ProposalID {
+	if less == nil {
+		less = byteOrder
+	}
 	result := maps.Keys(s.values)
-	sort.Slice(result, func(i, j int) bool { return bytes.Compare(result[i].Bytes(), result[j].Bytes()) == -1 })
+	sort.Slice(result, func(i, j int) bool { return less(result[i], result[j]) })
 
 	return result
 }
@@ -234,7 +260,7 @@ func (s *Set) Intersection(g *Set) *Set {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 
-	both := NewEmptySet(len(s.values))
+	both := NewEmptySet(len(s.values), WithOrder(s.less))
 	for v := range s.values {
 		if g.Contains(v) {
 			both.Add(v)
@@ -252,7 +278,7 @@ func (s *Set) Union(g *Set) *Set {
 	g.mu.RLock()
 	defer g.mu.RUnlock()
 
-	union := NewEmptySet(len(s.values) + len(g.values))
+	union := NewEmptySet(len(s.values)+len(g.values), WithOrder(s.less))
 
 	for v := range s.values {
 		union.values[v] = struct{}{}
@@ -270,7 +296,7 @@ func (s *Set) Complement(u *Set) *Set {
 	u.mu.RLock()
 	defer u.mu.RUnlock()
 
-	comp := NewEmptySet(len(u.values))
+	comp := NewEmptySet(len(u.values), WithOrder(s.less))
 	for v := range u.values {
 		if !s.Contains(v) {
 			comp.values[v] = struct{}{}
@@ -110,3 +110,49 @@ func TestSet_ToSlice(t *testing.T) {
 	sort.Slice(arr, func(i, j int) bool { return bytes.Compare(arr[i].Bytes(), arr[j].Bytes()) == -1 })
 	require.Equal(t, arr, res) // check result is sorted, required for order of set in commit msgs
 }
+
+func TestSet_ToSliceEqualSets(t *testing.T) {
+	s := NewSetFromValues(types.ProposalID{3}, types.ProposalID{1}, types.ProposalID{2})
+	g := NewEmptySet(0)
+	for _, id := range []types.ProposalID{{2}, {4}, {3}, {1}} {
+		g.Add(id)
+	}
+	g.Remove(types.ProposalID{4})
+	require.True(t, s.Equals(g))
+	require.Equal(t, s.ToSlice(), g.ToSlice())
+	require.Equal(t, s.ToSlice(), s.Clone().ToSlice())
+}
+
+func TestSet_Order(t *testing.T) {
+	arr := []types.ProposalID{{7}, {1}, {5}, {6}, {2}, {3}, {4}}
+	reversed := func(a, b types.ProposalID) bool { return bytes.Compare(a.Bytes(), b.Bytes()) == 1 }
+
+	def := NewSet(arr)
+	rev := NewSet(arr, WithOrder(reversed))
+	require.True(t, def.Equals(rev))
+	require.Equal(t, []types.ProposalID{{1}, {2}, {3}, {4}, {5}, {6}, {7}}, def.ToSlice())
+	require.Equal(t, []types.ProposalID{{7}, {6}, {5}, {4}, {3}, {2}, {1}}, rev.ToSlice())
+
+	// derived sets keep the order
+	g := NewSetFromValues(types.ProposalID{8}, types.ProposalID{1})
+	require.Equal(t, []types.ProposalID{{7}, {6}, {5}, {4}, {3}, {2}, {1}}, rev.Clone().ToSlice())
+	require.Equal(t, []types.ProposalID{{8}, {7}, {6}, {5}, {4}, {3}, {2}, {1}}, rev.Union(g).ToSlice())
+	require.Equal(t, []types.ProposalID{{1}}, rev.Intersection(g).ToSlice())
+	require.Equal(t, []types.ProposalID{{8}}, rev.Complement(rev.Union(g)).ToSlice())
+	require.Equal(t, []types.ProposalID{{1}, {8}}, NewEmptySet(0).Union(g).ToSlice())
+}
+
+func TestSet_IDIgnoresOrder(t *testing.T) {
+	reversed := func(a, b types.ProposalID) bool { return bytes.Compare(a.Bytes(), b.Bytes()) == 1 }
+	values := []types.ProposalID{{7}, {1}, {5}, {6}, {2}, {3}, {4}}
+
+	def := NewSet(values)
+	for _, s := range []*Set{
+		NewSet(values, WithOrder(reversed)),
+		NewSet([]types.ProposalID{{3}, {1}, {2}, {7}, {6}, {5}, {4}}, WithOrder(reversed)),
+		NewSet(values[:3], WithOrder(reversed)).Union(NewSet(values[3:])),
+	} {
+		require.True(t, def.Equals(s))
+		require.Equal(t, def.ID(), s.ID())
+	}
+}
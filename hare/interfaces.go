@@ -19,6 +19,7 @@ type Rolacle interface {
 	CalcEligibility(context.Context, types.LayerID, uint32, int, types.NodeID, types.VrfSignature) (uint16, error)
 	Proof(context.Context, types.LayerID, uint32) (types.VrfSignature, error)
 	IsIdentityActiveOnConsensusView(context.Context, types.NodeID, types.LayerID) (bool, error)
+	ActiveCount(context.Context, types.LayerID) (int, error)
 }
 
 // stateQuerier provides a query to check if an Ed public key is active on the current consensus view.
@@ -86,6 +86,8 @@ type syntaxContextValidator struct {
 	signing          *signing.EdSigner
 	edVerifier       *signing.EdVerifier
 	threshold        int
+	setSize          int                   // expected number of values in a proposed set, 0 to disable the check
+	setSizeTolerance int                   // how far the size of a proposed set may be from setSize
 	statusValidator  func(m *Message) bool // used to validate status Messages in SVP
 	stateQuerier     stateQuerier
 	roleValidator    roleValidator
@@ -97,7 +99,7 @@ type syntaxContextValidator struct {
 func newSyntaxContextValidator(
 	sgr *signing.EdSigner,
 	edVerifier *signing.EdVerifier,
-	threshold int,
+	threshold, setSize, setSizeTolerance int,
 	validator func(m *Message) bool,
 	stateQuerier stateQuerier,
 	ev roleValidator,
@@ -109,6 +111,8 @@ func newSyntaxContextValidator(
 		signing:          sgr,
 		edVerifier:       edVerifier,
 		threshold:        threshold,
+		setSize:          setSize,
+		setSizeTolerance: setSizeTolerance,
 		statusValidator:  validator,
 		stateQuerier:     stateQuerier,
 		roleValidator:    ev,
@@ -126,6 +130,7 @@ var (
 	errInvalidIter    = errors.New("incorrect iteration number")
 	errInvalidRound   = errors.New("incorrect round")
 	errUnexpectedType = errors.New("unexpected message type")
+	errSetSize        = errors.New("set size out of bounds")
 )
 
 // ContextuallyValidateMessage checks if the message is contextually valid.
@@ -173,6 +178,10 @@ func (v *syntaxContextValidator) ContextuallyValidateMessage(ctx context.Context
 		return errInvalidIter
 	}
 
+	if (m.Type == status || m.Type == proposal) && !v.validSetSize(len(m.Values)) {
+		return errSetSize
+	}
+
 	// check status, proposal & commit types
 	switch m.Type {
 	case status:
@@ -229,6 +238,14 @@ func (v *syntaxContextValidator) ContextuallyValidateMessage(ctx context.Context
 	return errUnexpectedType
 }
 
+// validSetSize returns true if a proposed set of the given size is within the tolerance of the expected size.
+func (v *syntaxContextValidator) validSetSize(size int) bool {
+	if v.setSize == 0 {
+		return true
+	}
+	return size >= v.setSize-v.setSizeTolerance && size <= v.setSize+v.setSizeTolerance
+}
+
 // SyntacticallyValidateMessage the syntax of the provided message.
 func (v *syntaxContextValidator) SyntacticallyValidateMessage(ctx context.Context, m *Message) bool {
 	logger := v.WithContext(ctx)
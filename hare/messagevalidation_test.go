@@ -33,7 +33,7 @@ func defaultValidator(tb testing.TB) *syntaxContextValidator {
 	edVerifier, err := signing.NewEdVerifier()
 	require.NoError(tb, err)
 
-	return newSyntaxContextValidator(signer, edVerifier, lowThresh10, trueValidator,
+	return newSyntaxContextValidator(signer, edVerifier, lowThresh10, 0, 0, trueValidator,
 		sq, truer{}, newPubGetter(), NewEligibilityTracker(lowThresh10), logtest.New(tb),
 	)
 }
@@ -347,7 +347,7 @@ func TestMessageValidator_SyntacticallyValidateMessage(t *testing.T) {
 	et := NewEligibilityTracker(100)
 	vfunc := func(m *Message) bool { return true }
 
-	sv := newSyntaxContextValidator(signer, edVerifier, 1, vfunc, nil, truer{}, newPubGetter(), et, logtest.New(t))
+	sv := newSyntaxContextValidator(signer, edVerifier, 1, 0, 0, vfunc, nil, truer{}, newPubGetter(), et, logtest.New(t))
 	m := BuildPreRoundMsg(signer, NewDefaultEmptySet(), types.EmptyVrfSignature)
 	require.True(t, sv.SyntacticallyValidateMessage(context.Background(), m))
 	m = BuildPreRoundMsg(signer, NewSetFromValues(types.RandomProposalID()), types.EmptyVrfSignature)
@@ -395,7 +395,7 @@ func TestMessageValidator_validateSVP(t *testing.T) {
 	mockStateQ.EXPECT().IsIdentityActiveOnConsensusView(gomock.Any(), gomock.Any(), gomock.Any()).Return(true, nil).AnyTimes()
 	et := NewEligibilityTracker(100)
 	vfunc := func(m *Message) bool { return true }
-	sv := newSyntaxContextValidator(signer, edVerifier, 1, vfunc, mockStateQ, truer{}, newPubGetter(), et, logtest.New(t))
+	sv := newSyntaxContextValidator(signer, edVerifier, 1, 0, 0, vfunc, mockStateQ, truer{}, newPubGetter(), et, logtest.New(t))
 	m := buildProposalMsg(signer, NewSetFromValues(types.ProposalID{1}, types.ProposalID{2}, types.ProposalID{3}), types.EmptyVrfSignature)
 	s1 := NewSetFromValues(types.ProposalID{1})
 	m.Svp = buildSVP(preRound, s1)
@@ -482,3 +482,37 @@ func TestSyntaxContextValidator_NotifyContextMatrix(t *testing.T) {
 	validateMatrix(t, notify, 3, msg3)
 	validateMatrix(t, notify, 7, msg7)
 }
+
+func TestSyntaxContextValidator_SetSize(t *testing.T) {
+	v := defaultValidator(t)
+	v.setSize = 4
+	v.setSizeTolerance = 1
+	signer, err := signing.NewEdSigner()
+	require.NoError(t, err)
+
+	for _, tc := range []struct {
+		desc string
+		size int
+		err  error
+	}{
+		{"under-sized", 2, errSetSize},
+		{"lower bound", 3, nil},
+		{"expected", 4, nil},
+		{"upper bound", 5, nil},
+		{"over-sized", 6, errSetSize},
+	} {
+		tc := tc
+		t.Run(tc.desc, func(t *testing.T) {
+			set := NewEmptySet(tc.size)
+			for i := 0; i < tc.size; i++ {
+				set.Add(types.ProposalID{byte(i + 1)})
+			}
+			st := BuildStatusMsg(signer, set)
+			st.Round = statusRound
+			require.Equal(t, tc.err, v.ContextuallyValidateMessage(context.Background(), st, statusRound))
+			prop := BuildProposalMsg(signer, set)
+			prop.Round = proposalRound
+			require.Equal(t, tc.err, v.ContextuallyValidateMessage(context.Background(), prop, proposalRound))
+		})
+	}
+}
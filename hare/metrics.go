@@ -12,6 +12,7 @@ const (
 	// labels for hare consensus output.
 	success = "ok"
 	failure = "fail"
+	timeout = "timeout"
 )
 
 var (
@@ -46,10 +47,25 @@ var (
 		prometheus.ExponentialBuckets(4, 2, 3),
 	).WithLabelValues()
 
+	roundWaitTime = metrics.NewHistogramWithBuckets(
+		"round_wait",
+		namespace,
+		"time (in seconds) a round waited for its threshold",
+		[]string{"round", "outcome"},
+		prometheus.ExponentialBuckets(0.1, 2, 10),
+	)
+
 	processesGauge = metrics.NewGauge(
 		"processes",
 		namespace,
 		"number of hare processes",
 		[]string{},
 	).WithLabelValues()
+
+	droppedMessages = metrics.NewCounter(
+		"dropped_messages",
+		namespace,
+		"number of messages dropped because the inbox of the consensus process was full",
+		[]string{},
+	).WithLabelValues()
 )
@@ -71,6 +71,21 @@ func (m *MockRolacle) EXPECT() *MockRolacleMockRecorder {
 	return m.recorder
 }
 
+// ActiveCount mocks base method.
+func (m *MockRolacle) ActiveCount(arg0 context.Context, arg1 types.LayerID) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ActiveCount", arg0, arg1)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ActiveCount indicates an expected call of ActiveCount.
+func (mr *MockRolacleMockRecorder) ActiveCount(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ActiveCount", reflect.TypeOf((*MockRolacle)(nil).ActiveCount), arg0, arg1)
+}
+
 // CalcEligibility mocks base method.
 func (m *MockRolacle) CalcEligibility(arg0 context.Context, arg1 types.LayerID, arg2 uint32, arg3 int, arg4 types.NodeID, arg5 types.VrfSignature) (uint16, error) {
 	m.ctrl.T.Helper()
@@ -92,6 +92,11 @@ func (nt *notifyTracker) NotificationsCount(s *Set) *CountInfo {
 	return nt.tracker.CountStatus(s.ID())
 }
 
+// Notifiers returns the eligible identities whose notifications for the provided set are counted.
+func (nt *notifyTracker) Notifiers(s *Set) []types.NodeID {
+	return nt.tracker.Voters(s.ID())
+}
+
 // calculates a unique id for the provided k and set.
 func calcID(k uint32, set *Set) types.Hash32 {
 	h := hash.New()
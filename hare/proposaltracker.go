@@ -5,6 +5,7 @@ import (
 	"context"
 
 	"github.com/spacemeshos/go-spacemesh/common/types"
+	"github.com/spacemeshos/go-spacemesh/hare/config"
 	"github.com/spacemeshos/go-spacemesh/log"
 )
 
@@ -13,6 +14,7 @@ type proposalTrackerProvider interface {
 	OnLateProposal(context.Context, *Message)
 	IsConflicting() bool
 	ProposedSet() *Set
+	Leader() (types.NodeID, bool)
 }
 
 // proposalTracker tracks proposal messages.
@@ -22,13 +24,42 @@ type proposalTracker struct {
 	proposal      *Message
 	isConflicting bool
 	eTracker      *EligibilityTracker
+	prefer        func(a, b *Message) bool // true if proposal a is selected over proposal b
 }
 
-func newProposalTracker(log log.Log, mch chan<- *types.MalfeasanceGossip, et *EligibilityTracker) *proposalTracker {
+func newProposalTracker(log log.Log, mch chan<- *types.MalfeasanceGossip, et *EligibilityTracker, selection config.ProposalSelection) *proposalTracker {
 	return &proposalTracker{
 		logger:   log,
 		malCh:    mch,
 		eTracker: et,
+		prefer:   proposalPreference(selection),
+	}
+}
+
+// proposalPreference returns the total order of proposals from different proposers for the selection rule.
+// ties are broken by the lowest role proof, so every node selects the same proposal regardless of
+// the order the proposals were received in.
+func proposalPreference(selection config.ProposalSelection) func(a, b *Message) bool {
+	lowerProof := func(a, b *Message) bool {
+		return bytes.Compare(a.Eligibility.Proof.Bytes(), b.Eligibility.Proof.Bytes()) < 0
+	}
+	switch selection {
+	case config.LowestID:
+		return func(a, b *Message) bool {
+			if c := bytes.Compare(a.SmesherID.Bytes(), b.SmesherID.Bytes()); c != 0 {
+				return c < 0
+			}
+			return lowerProof(a, b)
+		}
+	case config.HighestWeight:
+		return func(a, b *Message) bool {
+			if a.Eligibility.Count != b.Eligibility.Count {
+				return a.Eligibility.Count > b.Eligibility.Count
+			}
+			return lowerProof(a, b)
+		}
+	default:
+		return lowerProof
 	}
 }
 
@@ -83,12 +114,12 @@ func (pt *proposalTracker) OnProposal(ctx context.Context, msg *Message) {
 		return // process done
 	}
 
-	// ignore msgs with higher ranked role proof
-	if bytes.Compare(msg.Eligibility.Proof.Bytes(), pt.proposal.Eligibility.Proof.Bytes()) > 0 {
+	// ignore msgs that are not preferred over the current proposal
+	if !pt.prefer(msg, pt.proposal) {
 		return
 	}
 
-	pt.proposal = msg        // update lower leader msg
+	pt.proposal = msg        // update preferred leader msg
 	pt.isConflicting = false // assume no conflict
 }
 
@@ -140,8 +171,8 @@ func (pt *proposalTracker) OnLateProposal(ctx context.Context, msg *Message) {
 	}
 
 	// not equal check rank
-	// lower ranked proposal on late proposal is a conflict
-	if bytes.Compare(msg.Eligibility.Proof.Bytes(), pt.proposal.Eligibility.Proof.Bytes()) < 0 {
+	// preferred proposal on late proposal is a conflict
+	if pt.prefer(msg, pt.proposal) {
 		pt.logger.WithContext(ctx).With().Warning("late lower rank detected",
 			log.String("id_malicious", msg.SmesherID.String()),
 		)
@@ -166,3 +197,11 @@ func (pt *proposalTracker) ProposedSet() *Set {
 
 	return NewSet(pt.proposal.Values)
 }
+
+// Leader returns the identity whose proposal was accepted, false if no proposal was accepted.
+func (pt *proposalTracker) Leader() (types.NodeID, bool) {
+	if pt.ProposedSet() == nil {
+		return types.EmptyNodeID, false
+	}
+	return pt.proposal.SmesherID, true
+}
@@ -1,12 +1,14 @@
 package hare
 
 import (
+	"bytes"
 	"context"
 	"testing"
 
 	"github.com/stretchr/testify/require"
 
 	"github.com/spacemeshos/go-spacemesh/common/types"
+	"github.com/spacemeshos/go-spacemesh/hare/config"
 	"github.com/spacemeshos/go-spacemesh/log/logtest"
 	"github.com/spacemeshos/go-spacemesh/signing"
 )
@@ -32,7 +34,7 @@ func TestProposalTracker_OnProposalConflict(t *testing.T) {
 	m1 := BuildProposalMsg(signer, s)
 	et := NewEligibilityTracker(1)
 	mch := make(chan *types.MalfeasanceGossip, 1)
-	tracker := newProposalTracker(logtest.New(t), mch, et)
+	tracker := newProposalTracker(logtest.New(t), mch, et, config.LowestProof)
 	tracker.OnProposal(context.Background(), m1)
 	require.False(t, tracker.IsConflicting())
 	g := NewSetFromValues(types.ProposalID{3})
@@ -91,7 +93,7 @@ func TestProposalTracker_IsConflicting(t *testing.T) {
 	s.Add(types.ProposalID{1})
 	et := NewEligibilityTracker(1)
 	mch := make(chan *types.MalfeasanceGossip, 1)
-	tracker := newProposalTracker(logtest.New(t), mch, et)
+	tracker := newProposalTracker(logtest.New(t), mch, et, config.LowestProof)
 
 	for i := 0; i < lowThresh10; i++ {
 		signer, err := signing.NewEdSigner()
@@ -110,7 +112,7 @@ func TestProposalTracker_OnLateProposal(t *testing.T) {
 	m1 := BuildProposalMsg(signer, s)
 	et := NewEligibilityTracker(1)
 	mch := make(chan *types.MalfeasanceGossip, 1)
-	tracker := newProposalTracker(logtest.New(t), mch, et)
+	tracker := newProposalTracker(logtest.New(t), mch, et, config.LowestProof)
 	tracker.OnProposal(context.Background(), m1)
 	require.False(t, tracker.IsConflicting())
 	g := NewSetFromValues(types.ProposalID{3})
@@ -167,7 +169,7 @@ func TestProposalTracker_OnLateProposal(t *testing.T) {
 func TestProposalTracker_ProposedSet(t *testing.T) {
 	et := NewEligibilityTracker(1)
 	mch := make(chan *types.MalfeasanceGossip, 1)
-	tracker := newProposalTracker(logtest.New(t), mch, et)
+	tracker := newProposalTracker(logtest.New(t), mch, et, config.LowestProof)
 	proposedSet := tracker.ProposedSet()
 	require.Nil(t, proposedSet)
 
@@ -240,3 +242,43 @@ func TestProposalTracker_ProposedSet(t *testing.T) {
 		require.EqualValues(t, 1, cred.Count)
 	})
 }
+
+func TestProposalTracker_Selection(t *testing.T) {
+	signer1, err := signing.NewEdSigner()
+	require.NoError(t, err)
+	signer2, err := signing.NewEdSigner()
+	require.NoError(t, err)
+	if bytes.Compare(signer1.NodeID().Bytes(), signer2.NodeID().Bytes()) > 0 {
+		signer1, signer2 = signer2, signer1
+	}
+
+	// the first proposal has the lowest proposer id, the second the lowest role proof and the highest weight
+	s1 := NewSetFromValues(types.ProposalID{1})
+	m1 := buildProposalMsg(signer1, s1, types.VrfSignature{2})
+	s2 := NewSetFromValues(types.ProposalID{2})
+	m2 := buildProposalMsg(signer2, s2, types.VrfSignature{1})
+	m2.Eligibility.Count = 2
+
+	for _, tc := range []struct {
+		selection config.ProposalSelection
+		expected  *Set
+	}{
+		{"", s2},
+		{config.LowestProof, s2},
+		{config.LowestID, s1},
+		{config.HighestWeight, s2},
+	} {
+		tc := tc
+		t.Run(string(tc.selection), func(t *testing.T) {
+			// every node selects the same proposal regardless of the arrival order
+			for _, order := range [][]*Message{{m1, m2}, {m2, m1}} {
+				tracker := newProposalTracker(logtest.New(t), make(chan *types.MalfeasanceGossip, 1), NewEligibilityTracker(2), tc.selection)
+				for _, m := range order {
+					tracker.OnProposal(context.Background(), m)
+				}
+				require.False(t, tracker.IsConflicting())
+				require.True(t, tc.expected.Equals(tracker.ProposedSet()))
+			}
+		})
+	}
+}
@@ -1,6 +1,11 @@
 package hare
 
-import "github.com/spacemeshos/go-spacemesh/common/types"
+import (
+	"bytes"
+	"sort"
+
+	"github.com/spacemeshos/go-spacemesh/common/types"
+)
 
 type item struct {
 	counts map[types.NodeID]struct{}
@@ -53,3 +58,22 @@ func (rt *RefCountTracker) Track(id any, nodeID types.NodeID) {
 	}
 	rt.table[id].counts[nodeID] = struct{}{}
 }
+
+// Voters returns the eligible identities whose references to the given id are counted, ordered by id.
+func (rt *RefCountTracker) Voters(id any) []types.NodeID {
+	if _, ok := rt.table[id]; !ok {
+		return nil
+	}
+
+	votes := rt.table[id].counts
+	var voters []types.NodeID
+	rt.eTracker.ForEach(rt.round, func(nodeID types.NodeID, _ *Cred) {
+		if _, ok := votes[nodeID]; ok {
+			voters = append(voters, nodeID)
+		}
+	})
+	sort.Slice(voters, func(i, j int) bool {
+		return bytes.Compare(voters[i].Bytes(), voters[j].Bytes()) < 0
+	})
+	return voters
+}
@@ -77,3 +77,19 @@ func TestRefCountTracker_CountStatus_Eligibility(t *testing.T) {
 	expected = CountInfo{hCount: 2, dhCount: 1, keCount: 5, numHonest: 1, numDishonest: 1, numKE: 1}
 	require.Equal(t, expected, *tracker.CountStatus(myInt.ID()))
 }
+
+func TestRefCountTracker_Voters(t *testing.T) {
+	et := NewEligibilityTracker(5)
+	tracker := NewRefCountTracker(preRound, et, 5)
+	require.Empty(t, tracker.Voters(uint32(1)))
+
+	first, second, ineligible := types.NodeID{1}, types.NodeID{2}, types.NodeID{3}
+	et.Track(second, preRound, 1, true)
+	et.Track(first, preRound, 1, false)
+	tracker.Track(uint32(1), second)
+	tracker.Track(uint32(1), first)
+	tracker.Track(uint32(1), ineligible)
+	tracker.Track(uint32(2), ineligible)
+	require.Equal(t, []types.NodeID{first, second}, tracker.Voters(uint32(1)))
+	require.Empty(t, tracker.Voters(uint32(2)))
+}
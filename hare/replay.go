@@ -0,0 +1,146 @@
+package hare
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/spacemeshos/go-spacemesh/common/types"
+	"github.com/spacemeshos/go-spacemesh/hare/config"
+	"github.com/spacemeshos/go-spacemesh/log"
+	"github.com/spacemeshos/go-spacemesh/signing"
+)
+
+var (
+	errReplayNotTerminated = errors.New("recorded messages are not enough to terminate")
+	errReplayNotCompleted  = errors.New("consensus did not complete")
+)
+
+// discardPublisher drops the messages of a replayed consensus process.
+type discardPublisher struct{}
+
+func (discardPublisher) Publish(context.Context, string, []byte) error {
+	return nil
+}
+
+// ReplayInstance reconstructs the decision of a consensus process for the layer from the recorded
+// hare messages it received, for audit and debugging.
+// initial is the set of values the recorded process started with. The messages are validated as the
+// broker does and fed to a passive consensus process round by round, in the order they were recorded,
+// so the same inputs always yield the same output.
+func ReplayInstance(
+	ctx context.Context,
+	cfg config.Config,
+	layer types.LayerID,
+	initial *Set,
+	msgs [][]byte,
+	oracle Rolacle,
+	stateQ stateQuerier,
+	logger log.Log,
+) (*Set, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid config: %w", err)
+	}
+	ev := newEligibilityValidator(oracle, cfg.N, cfg.ExpectedLeaders, logger)
+	return replayInstance(ctx, cfg, layer, initial, msgs, oracle, stateQ, ev, logger)
+}
+
+func replayInstance(
+	ctx context.Context,
+	cfg config.Config,
+	layer types.LayerID,
+	initial *Set,
+	msgs [][]byte,
+	oracle Rolacle,
+	stateQ stateQuerier,
+	ev roleValidator,
+	logger log.Log,
+) (*Set, error) {
+	edVerifier, err := signing.NewEdVerifier()
+	if err != nil {
+		return nil, fmt.Errorf("create verifier: %w", err)
+	}
+	// the replayed process never publishes, it only needs an identity to run
+	signer, err := signing.NewEdSigner()
+	if err != nil {
+		return nil, fmt.Errorf("create signer: %w", err)
+	}
+
+	rounds := make(map[uint32][]*Message)
+	last := preRound
+	for _, buf := range msgs {
+		msg, err := MessageFromBuffer(buf)
+		if err != nil {
+			return nil, err
+		}
+		if msg.InnerMessage == nil || msg.Layer != layer {
+			continue
+		}
+		if !edVerifier.Verify(signing.HARE, msg.SmesherID, msg.SignedBytes(), msg.Signature) {
+			logger.With().Warning("replay: discarding message with invalid signature", log.Stringer("smesher", msg.SmesherID))
+			continue
+		}
+		msg.signedHash = types.BytesToHash(msg.InnerMessage.HashBytes())
+		if err := checkIdentity(ctx, logger, msg, stateQ); err != nil {
+			logger.With().Warning("replay: discarding message from invalid identity", log.Err(err))
+			continue
+		}
+		if !ev.Validate(ctx, msg) {
+			logger.With().Warning("replay: discarding message from ineligible identity", log.Stringer("smesher", msg.SmesherID))
+			continue
+		}
+		rounds[msg.Round] = append(rounds[msg.Round], msg)
+		if msg.Round != preRound && (last == preRound || msg.Round > last) {
+			last = msg.Round
+		}
+	}
+
+	mch := make(chan *types.MalfeasanceGossip, cfg.N)
+	defer close(mch)
+	go func() {
+		for range mch {
+		}
+	}()
+	output := make(chan report, 1)
+	comm := communication{
+		inbox:  make(chan any),
+		mchOut: mch,
+		report: output,
+		wc:     make(chan wcReport, 1),
+	}
+	proc := newConsensusProcess(ctx, cfg, layer, initial, oracle, stateQ, signer, edVerifier,
+		NewEligibilityTracker(cfg.N), signer.NodeID(), discardPublisher{}, comm, ev, nil,
+		NewSimpleRoundClock(time.Now(), 0, cfg.RoundDuration), logger)
+	defer proc.terminate()
+
+	for _, msg := range rounds[preRound] {
+		proc.handleMessage(ctx, msg)
+	}
+	proc.onPreRoundEnd(ctx)
+	for running := true; running; running = proc.nextRound(ctx) {
+		round := proc.getRound()
+		if last == preRound || round > last {
+			return nil, fmt.Errorf("%w: round %d", errReplayNotTerminated, round)
+		}
+		for _, msg := range rounds[round] {
+			proc.handleMessage(ctx, msg)
+			if proc.terminating() {
+				break
+			}
+		}
+		if proc.terminating() {
+			break
+		}
+	}
+
+	select {
+	case out := <-output:
+		if !out.completed {
+			return nil, errReplayNotCompleted
+		}
+		return out.set, nil
+	default:
+		return nil, errReplayNotCompleted
+	}
+}
@@ -0,0 +1,98 @@
+package hare
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/stretchr/testify/require"
+
+	"github.com/spacemeshos/go-spacemesh/common/types"
+	"github.com/spacemeshos/go-spacemesh/eligibility"
+	"github.com/spacemeshos/go-spacemesh/hare/config"
+	"github.com/spacemeshos/go-spacemesh/hare/mocks"
+	"github.com/spacemeshos/go-spacemesh/log/logtest"
+	"github.com/spacemeshos/go-spacemesh/p2p/pubsub"
+)
+
+// recordingPubSub records the messages delivered to the registered handlers.
+type recordingPubSub struct {
+	pubsub.PublishSubsciber
+
+	mu   sync.Mutex
+	msgs [][]byte
+}
+
+func (r *recordingPubSub) Register(protocol string, handler pubsub.GossipHandler) {
+	r.PublishSubsciber.Register(protocol, func(ctx context.Context, pid peer.ID, msg []byte) error {
+		r.mu.Lock()
+		r.msgs = append(r.msgs, append([]byte(nil), msg...))
+		r.mu.Unlock()
+		return handler(ctx, pid, msg)
+	})
+}
+
+func (r *recordingPubSub) recorded() [][]byte {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([][]byte(nil), r.msgs...)
+}
+
+func TestReplayInstance(t *testing.T) {
+	const totalNodes = 5
+	cfg := config.Config{N: totalNodes, RoundDuration: time.Second, ExpectedLeaders: 5, LimitIterations: 1000, Hdist: 20}
+
+	set1 := NewSetFromValues(types.ProposalID{1}, types.ProposalID{2})
+	set2 := NewSetFromValues(types.ProposalID{1}, types.ProposalID{2}, types.ProposalID{3})
+	initial := make([]*Set, totalNodes)
+	for i := range initial {
+		initial[i] = set1
+		if i%2 == 1 {
+			initial[i] = set2
+		}
+	}
+
+	recorder := &recordingPubSub{}
+	outputs, err := runConsensusWith(t, totalNodes, cfg, initial, 30*time.Second,
+		func(i int, ps pubsub.PublishSubsciber) pubsub.PublishSubsciber {
+			if i != 0 {
+				return ps
+			}
+			recorder.PublishSubsciber = ps
+			return recorder
+		})
+	require.NoError(t, err)
+	msgs := recorder.recorded()
+	require.NotEmpty(t, msgs)
+
+	sq := mocks.NewMockstateQuerier(gomock.NewController(t))
+	sq.EXPECT().IsIdentityActiveOnConsensusView(gomock.Any(), gomock.Any(), gomock.Any()).Return(true, nil).AnyTimes()
+	replay := func() (*Set, error) {
+		return replayInstance(context.Background(), cfg, instanceID1, initial[0], msgs,
+			eligibility.New(logtest.New(t)), sq, truer{}, logtest.New(t))
+	}
+	got, err := replay()
+	require.NoError(t, err)
+	require.True(t, outputs[0].Equals(got), "expected %v, got %v", outputs[0], got)
+
+	// deterministic given the same inputs
+	again, err := replay()
+	require.NoError(t, err)
+	require.True(t, got.Equals(again))
+
+	// the decision can't be reconstructed without the notifications
+	var partial [][]byte
+	for _, buf := range msgs {
+		msg, err := MessageFromBuffer(buf)
+		require.NoError(t, err)
+		if msg.Type != notify {
+			partial = append(partial, buf)
+		}
+	}
+	_, err = replayInstance(context.Background(), cfg, instanceID1, initial[0], partial,
+		eligibility.New(logtest.New(t)), sq, truer{}, logtest.New(t))
+	require.ErrorIs(t, err, errReplayNotTerminated)
+}
@@ -0,0 +1,64 @@
+package hare
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+
+	"github.com/spacemeshos/go-spacemesh/common/types"
+)
+
+// ValueCodec maps the values of an application to the values hare runs consensus over.
+// Encode must be injective, so that nodes that agree on a set of encoded values agree on
+// the application values. Less defines the order of the decoded values.
+type ValueCodec[T any] interface {
+	Encode(T) (types.ProposalID, error)
+	Decode(types.ProposalID) (T, error)
+	Less(a, b T) bool
+}
+
+// ProposalIDCodec is the identity codec used for consensus over proposals.
+type ProposalIDCodec struct{}
+
+// Encode implements ValueCodec.
+func (ProposalIDCodec) Encode(id types.ProposalID) (types.ProposalID, error) {
+	return id, nil
+}
+
+// Decode implements ValueCodec.
+func (ProposalIDCodec) Decode(id types.ProposalID) (types.ProposalID, error) {
+	return id, nil
+}
+
+// Less implements ValueCodec.
+func (ProposalIDCodec) Less(a, b types.ProposalID) bool {
+	return bytes.Compare(a.Bytes(), b.Bytes()) < 0
+}
+
+// EncodeSet creates a set of the encoded values.
+func EncodeSet[T any](codec ValueCodec[T], values []T) (*Set, error) {
+	s := NewEmptySet(len(values))
+	for _, v := range values {
+		id, err := codec.Encode(v)
+		if err != nil {
+			return nil, fmt.Errorf("encode value %v: %w", v, err)
+		}
+		s.Add(id)
+	}
+	return s, nil
+}
+
+// DecodeSet returns the decoded values of the set in the order of the codec.
+func DecodeSet[T any](codec ValueCodec[T], s *Set) ([]T, error) {
+	ids := s.ToSlice()
+	values := make([]T, 0, len(ids))
+	for _, id := range ids {
+		v, err := codec.Decode(id)
+		if err != nil {
+			return nil, fmt.Errorf("decode value %v: %w", id, err)
+		}
+		values = append(values, v)
+	}
+	sort.Slice(values, func(i, j int) bool { return codec.Less(values[i], values[j]) })
+	return values, nil
+}
@@ -0,0 +1,77 @@
+package hare
+
+import (
+	"encoding/binary"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/spacemeshos/go-spacemesh/common/types"
+	"github.com/spacemeshos/go-spacemesh/hare/config"
+)
+
+// heightCodec encodes heights in the first 8 bytes of the id.
+type heightCodec struct{}
+
+func (heightCodec) Encode(h uint64) (types.ProposalID, error) {
+	var id types.ProposalID
+	binary.BigEndian.PutUint64(id[:], h)
+	return id, nil
+}
+
+func (heightCodec) Decode(id types.ProposalID) (uint64, error) {
+	for _, b := range id[8:] {
+		if b != 0 {
+			return 0, errors.New("not a height")
+		}
+	}
+	return binary.BigEndian.Uint64(id[:]), nil
+}
+
+func (heightCodec) Less(a, b uint64) bool {
+	return a > b
+}
+
+func TestValueCodec(t *testing.T) {
+	s, err := EncodeSet[uint64](heightCodec{}, []uint64{1, 300, 20, 300})
+	require.NoError(t, err)
+	require.Equal(t, 3, s.Size())
+	values, err := DecodeSet[uint64](heightCodec{}, s)
+	require.NoError(t, err)
+	require.Equal(t, []uint64{300, 20, 1}, values)
+
+	s.Add(types.RandomProposalID())
+	_, err = DecodeSet[uint64](heightCodec{}, s)
+	require.Error(t, err)
+
+	ids := []types.ProposalID{{3}, {1}, {2}}
+	s, err = EncodeSet[types.ProposalID](ProposalIDCodec{}, ids)
+	require.NoError(t, err)
+	decoded, err := DecodeSet[types.ProposalID](ProposalIDCodec{}, s)
+	require.NoError(t, err)
+	require.Equal(t, []types.ProposalID{{1}, {2}, {3}}, decoded)
+}
+
+func TestConsensus_CustomValues(t *testing.T) {
+	const totalNodes = 5
+	cfg := config.Config{N: totalNodes, RoundDuration: time.Second, ExpectedLeaders: 5, LimitIterations: 1000, Hdist: 20}
+
+	var codec heightCodec
+	initial := make([]*Set, totalNodes)
+	for i := range initial {
+		s, err := EncodeSet[uint64](codec, []uint64{10, 20, 30})
+		require.NoError(t, err)
+		initial[i] = s
+	}
+
+	outputs, err := runConsensus(t, totalNodes, cfg, initial, 30*time.Second)
+	require.NoError(t, err)
+	require.Len(t, outputs, totalNodes)
+	for _, out := range outputs {
+		values, err := DecodeSet[uint64](codec, out)
+		require.NoError(t, err)
+		require.Equal(t, []uint64{30, 20, 10}, values)
+	}
+}
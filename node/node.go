@@ -433,6 +433,10 @@ func (app *App) Initialize() error {
 		}
 	}
 
+	if err := app.Config.HARE.Validate(); err != nil {
+		return fmt.Errorf("invalid hare config: %w", err)
+	}
+
 	// tortoise wait zdist layers for hare to timeout for a layer. once hare timeout, tortoise will
 	// vote against all blocks in that layer. so it's important to make sure zdist takes longer than
 	// hare's max time duration to run consensus for a layer
@@ -1144,7 +1148,9 @@ func (app *App) stopServices(ctx context.Context) {
 	}
 
 	if app.hare != nil {
-		app.hare.Close()
+		if err := app.hare.Shutdown(ctx); err != nil {
+			log.With().Info("hare stopped before the end of the round", log.Err(err))
+		}
 	}
 
 	if app.blockGen != nil {
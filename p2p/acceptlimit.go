@@ -0,0 +1,53 @@
+package p2p
+
+import (
+	"net"
+	"sync"
+
+	"golang.org/x/time/rate"
+)
+
+// maxTrackedIPs is the number of ip addresses the accept limiter tracks before it evicts idle limiters.
+const maxTrackedIPs = 10000
+
+// acceptLimiter throttles inbound connections from a single ip address with a token bucket.
+type acceptLimiter struct {
+	limit rate.Limit
+	burst int
+
+	mu       sync.Mutex
+	limiters map[string]*rate.Limiter
+}
+
+func newAcceptLimiter(limit float64, burst int) *acceptLimiter {
+	return &acceptLimiter{
+		limit:    rate.Limit(limit),
+		burst:    burst,
+		limiters: map[string]*rate.Limiter{},
+	}
+}
+
+// allow returns true if a connection from the ip address is within the rate limit.
+func (l *acceptLimiter) allow(ip net.IP) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	key := ip.String()
+	lim, exist := l.limiters[key]
+	if !exist {
+		if len(l.limiters) >= maxTrackedIPs {
+			l.evictIdle()
+		}
+		lim = rate.NewLimiter(l.limit, l.burst)
+		l.limiters[key] = lim
+	}
+	return lim.Allow()
+}
+
+// evictIdle removes limiters with a full bucket, they are equivalent to new limiters.
+func (l *acceptLimiter) evictIdle() {
+	for key, lim := range l.limiters {
+		if lim.Tokens() >= float64(l.burst) {
+			delete(l.limiters, key)
+		}
+	}
+}
@@ -0,0 +1,49 @@
+package p2p
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/time/rate"
+)
+
+func TestAcceptLimiter(t *testing.T) {
+	l := newAcceptLimiter(0.001, 3)
+	ip := net.ParseIP("1.2.3.4")
+	for i := 0; i < 3; i++ {
+		require.True(t, l.allow(ip))
+	}
+	require.False(t, l.allow(ip))
+	// other addresses are not affected
+	require.True(t, l.allow(net.ParseIP("1.2.3.5")))
+}
+
+func TestAcceptLimiter_EvictIdle(t *testing.T) {
+	l := newAcceptLimiter(0.001, 1)
+	throttled := net.ParseIP("1.2.3.4")
+	require.True(t, l.allow(throttled))
+	for i := 0; i < maxTrackedIPs; i++ {
+		l.limiters[net.IPv4(10, byte(i>>16), byte(i>>8), byte(i)).String()] = rate.NewLimiter(l.limit, l.burst)
+	}
+	require.True(t, l.allow(net.ParseIP("1.2.3.5")))
+	require.Len(t, l.limiters, 2)
+	require.False(t, l.allow(throttled))
+}
+
+func TestAcceptRate(t *testing.T) {
+	cfg := testConfig(t)
+	cfg.AcceptRate = 0.001
+	cfg.AcceptBurst = 2
+	h1 := newTestHost(t, testConfig(t))
+	h2 := newTestHost(t, cfg)
+
+	info := peer.AddrInfo{ID: h2.ID(), Addrs: h2.Addrs()}
+	for i := 0; i < cfg.AcceptBurst; i++ {
+		require.NoError(t, h1.Connect(context.Background(), info))
+		require.NoError(t, h1.Network().ClosePeer(h2.ID()))
+	}
+	require.Error(t, h1.Connect(context.Background(), info))
+}
@@ -162,8 +162,7 @@ func (b *Book) Add(src, id ID, raw Address) {
 			return
 		}
 	}
-	if addr == nil && len(b.known) == b.limit {
-		// future improvement is to find any stale and replace it with learned
+	if addr == nil && len(b.known) >= b.limit && !b.evictStale() {
 		return
 	} else if addr == nil {
 		addr = &addressInfo{
@@ -240,6 +239,44 @@ func (b *Book) Update(id ID, events ...Event) {
 	}
 }
 
+// evictStale forgets the stale address with the most failures to make room for a learned address.
+// Connected and protected addresses are never evicted.
+// Returns false if there is no address to evict.
+func (b *Book) evictStale() bool {
+	var evict *addressInfo
+	for _, addr := range b.known {
+		if addr.Class != stale || addr.Connected || addr.protected {
+			continue
+		}
+		if evict == nil || addr.failures > evict.failures ||
+			(addr.failures == evict.failures && addr.ID < evict.ID) {
+			evict = addr
+		}
+	}
+	if evict == nil {
+		return false
+	}
+	delete(b.known, evict.ID)
+	// queue and shareable drop deleted entries lazily
+	evict.Class = deleted
+	return true
+}
+
+// Remove forgets the address of the peer, including protected addresses.
+// It is no longer shared, dialed or persisted.
+func (b *Book) Remove(id ID) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	addr := b.known[id]
+	if addr == nil {
+		return
+	}
+	delete(b.known, id)
+	// queue and shareable drop deleted entries lazily
+	addr.Class = deleted
+	addr.protected = false
+}
+
 func (b *Book) DrainQueue(n int) []Address {
 	b.mu.Lock()
 	defer b.mu.Unlock()
@@ -77,6 +77,12 @@ func share(src book.ID, n int, ids ...book.ID) step {
 	}
 }
 
+func remove(id book.ID) step {
+	return func(ts *testState) {
+		ts.book.Remove(id)
+	}
+}
+
 func shareExpectNil(src book.ID, n int) step {
 	return func(ts *testState) {
 		require.Nil(ts, ts.book.TakeShareable(src, n))
@@ -285,6 +291,21 @@ func TestBook(t *testing.T) {
 			add("5", "/ip4/0.0.0.0/tcp/5555"),
 			drain(5, "1", "2", "3", "4"),
 		}},
+		{"stale is evicted after limit is reached", []step{
+			add("1", "/ip4/0.0.0.0/tcp/1111"),
+			add("2", "/ip4/0.0.0.0/tcp/2222"),
+			add("3", "/ip4/0.0.0.0/tcp/3333"),
+			add("4", "/ip4/0.0.0.0/tcp/4444"),
+			update("2", book.Fail),
+			update("3", book.Fail),
+			update("3", book.Connected),
+			add("5", "/ip4/0.0.0.0/tcp/5555"),
+			stats(book.Stats{Total: 4, Connected: 1, Private: 4, Stale: 1, Learned: 3}),
+			drain(5, "1", "3", "4", "3", "5"),
+			// the only stale address is connected
+			add("6", "/ip4/0.0.0.0/tcp/6666"),
+			stats(book.Stats{Total: 4, Connected: 1, Private: 4, Stale: 1, Learned: 3}),
+		}},
 		{"updated address preserves its state", []step{
 			add("1", "/ip4/0.0.0.0/tcp/1111"),
 			add("2", "/ip4/0.0.0.0/tcp/2222"),
@@ -354,6 +375,25 @@ func TestBook(t *testing.T) {
 			),
 			drain(2, "2"),
 		}},
+		{"removed is forgotten", []step{
+			add("1", "/ip4/0.0.0.0/tcp/1111"),
+			add("2", "/ip4/0.0.0.0/tcp/2222"),
+			add("3", "/ip4/0.0.0.0/tcp/3333"),
+			update("1", book.Protect),
+			remove("1"),
+			remove("2"),
+			remove("4"),
+			drain(3, "3"),
+			shareExpectNil("2", 3),
+			func(ts *testState) {
+				require.Empty(ts, ts.book.TakeShareable("3", 3))
+			},
+			stats(book.Stats{Total: 1, Private: 1, Learned: 1}),
+			persist(`
+{"id":"3","raw":"/ip4/0.0.0.0/tcp/3333","class":2,"connected":false}
+8242195110450939374
+`),
+		}},
 		{"persist nothing", []step{
 			persist("0"),
 		}},
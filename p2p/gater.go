@@ -0,0 +1,134 @@
+package p2p
+
+import (
+	"fmt"
+	"net"
+	"sync"
+
+	"github.com/libp2p/go-libp2p/core/control"
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/peer"
+	ma "github.com/multiformats/go-multiaddr"
+	manet "github.com/multiformats/go-multiaddr/net"
+)
+
+// addressGater rejects inbound connections from and outbound dials to the ip addresses
+// that are not permitted by the allow and deny lists.
+// deny list has precedence over the allow list. if the allow list is empty all addresses
+// that are not denied are permitted.
+// inbound connections are also throttled per ip address if limiter is set.
+// handshakes of the admitted connections are timed if handshakes is set.
+type addressGater struct {
+	mu         sync.RWMutex
+	allow      []*net.IPNet
+	deny       []*net.IPNet
+	limiter    *acceptLimiter
+	handshakes *handshakeTimer
+}
+
+func newAddressGater(allow, deny []string) (*addressGater, error) {
+	g := &addressGater{}
+	if err := g.update(allow, deny); err != nil {
+		return nil, err
+	}
+	return g, nil
+}
+
+func parseCIDRs(cidrs []string) ([]*net.IPNet, error) {
+	nets := make([]*net.IPNet, 0, len(cidrs))
+	for _, cidr := range cidrs {
+		_, ipnet, err := net.ParseCIDR(cidr)
+		if err != nil {
+			return nil, fmt.Errorf("parse cidr %s: %w", cidr, err)
+		}
+		nets = append(nets, ipnet)
+	}
+	return nets, nil
+}
+
+// update replaces the allow and deny lists. the lists are left unchanged if any cidr is invalid.
+func (g *addressGater) update(allow, deny []string) error {
+	allowNets, err := parseCIDRs(allow)
+	if err != nil {
+		return err
+	}
+	denyNets, err := parseCIDRs(deny)
+	if err != nil {
+		return err
+	}
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.allow = allowNets
+	g.deny = denyNets
+	return nil
+}
+
+func (g *addressGater) allowed(addr ma.Multiaddr) bool {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	ip, err := manet.ToIP(addr)
+	if err != nil {
+		// not an ip address (e.g. unresolved dns), can't be matched against the lists
+		return len(g.allow) == 0
+	}
+	for _, ipnet := range g.deny {
+		if ipnet.Contains(ip) {
+			return false
+		}
+	}
+	if len(g.allow) == 0 {
+		return true
+	}
+	for _, ipnet := range g.allow {
+		if ipnet.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// InterceptPeerDial implements connmgr.ConnectionGater.
+func (g *addressGater) InterceptPeerDial(peer.ID) bool {
+	return true
+}
+
+// InterceptAddrDial implements connmgr.ConnectionGater.
+func (g *addressGater) InterceptAddrDial(id peer.ID, addr ma.Multiaddr) bool {
+	if !g.allowed(addr) {
+		return false
+	}
+	if g.handshakes != nil {
+		g.handshakes.start(network.DirOutbound, id, addr)
+	}
+	return true
+}
+
+// InterceptAccept implements connmgr.ConnectionGater.
+func (g *addressGater) InterceptAccept(addrs network.ConnMultiaddrs) bool {
+	addr := addrs.RemoteMultiaddr()
+	if !g.allowed(addr) {
+		return false
+	}
+	if g.limiter != nil {
+		if ip, err := manet.ToIP(addr); err == nil && !g.limiter.allow(ip) {
+			return false
+		}
+	}
+	if g.handshakes != nil {
+		g.handshakes.start(network.DirInbound, "", addr)
+	}
+	return true
+}
+
+// InterceptSecured implements connmgr.ConnectionGater.
+func (g *addressGater) InterceptSecured(dir network.Direction, id peer.ID, addrs network.ConnMultiaddrs) bool {
+	if g.handshakes != nil {
+		g.handshakes.secured(dir, id, addrs.RemoteMultiaddr())
+	}
+	return true
+}
+
+// InterceptUpgraded implements connmgr.ConnectionGater.
+func (g *addressGater) InterceptUpgraded(network.Conn) (bool, control.DisconnectReason) {
+	return true, 0
+}
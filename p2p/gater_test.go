@@ -0,0 +1,69 @@
+package p2p
+
+import (
+	"context"
+	"testing"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+	ma "github.com/multiformats/go-multiaddr"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAddressGater(t *testing.T) {
+	for _, tc := range []struct {
+		desc        string
+		allow, deny []string
+		addr        string
+		allowed     bool
+	}{
+		{desc: "default", addr: "/ip4/1.2.3.4/tcp/7513", allowed: true},
+		{desc: "default dns", addr: "/dns4/bootnode.spacemesh.io/tcp/5003", allowed: true},
+		{desc: "denied", deny: []string{"1.2.0.0/16"}, addr: "/ip4/1.2.3.4/tcp/7513"},
+		{desc: "not denied", deny: []string{"1.2.0.0/16"}, addr: "/ip4/1.3.3.4/tcp/7513", allowed: true},
+		{desc: "allowed", allow: []string{"1.2.0.0/16"}, addr: "/ip4/1.2.3.4/tcp/7513", allowed: true},
+		{desc: "not allowed", allow: []string{"1.2.0.0/16"}, addr: "/ip4/1.3.3.4/tcp/7513"},
+		{desc: "not allowed dns", allow: []string{"1.2.0.0/16"}, addr: "/dns4/bootnode.spacemesh.io/tcp/5003"},
+		{
+			desc:  "deny has precedence",
+			allow: []string{"1.2.0.0/16"}, deny: []string{"1.2.3.0/24"},
+			addr: "/ip4/1.2.3.4/tcp/7513",
+		},
+		{desc: "ipv6 denied", deny: []string{"2001:db8::/32"}, addr: "/ip6/2001:db8::1/tcp/7513"},
+	} {
+		tc := tc
+		t.Run(tc.desc, func(t *testing.T) {
+			g, err := newAddressGater(tc.allow, tc.deny)
+			require.NoError(t, err)
+			addr := ma.StringCast(tc.addr)
+			require.Equal(t, tc.allowed, g.InterceptAddrDial("", addr))
+			require.Equal(t, tc.allowed, g.allowed(addr))
+		})
+	}
+}
+
+func TestAddressGater_InvalidCIDR(t *testing.T) {
+	_, err := newAddressGater([]string{"1.2.3.4"}, nil)
+	require.Error(t, err)
+
+	g, err := newAddressGater(nil, []string{"1.2.0.0/16"})
+	require.NoError(t, err)
+	require.Error(t, g.update(nil, []string{"1.3.0.0/16", "invalid"}))
+	// lists are unchanged
+	require.False(t, g.allowed(ma.StringCast("/ip4/1.2.3.4/tcp/7513")))
+	require.True(t, g.allowed(ma.StringCast("/ip4/1.3.3.4/tcp/7513")))
+}
+
+func TestAddressFilters(t *testing.T) {
+	cfg := testConfig(t)
+	cfg.DenyCIDRs = []string{"127.0.0.0/8"}
+	h1 := newTestHost(t, testConfig(t))
+	h2 := newTestHost(t, cfg)
+
+	// inbound connection is rejected by h2
+	require.Error(t, h1.Connect(context.Background(), peer.AddrInfo{ID: h2.ID(), Addrs: h2.Addrs()}))
+	// outbound dial is refused by h2
+	require.Error(t, h2.Connect(context.Background(), peer.AddrInfo{ID: h1.ID(), Addrs: h1.Addrs()}))
+
+	require.NoError(t, h2.UpdateAddressFilters(nil, nil))
+	require.NoError(t, h2.Connect(context.Background(), peer.AddrInfo{ID: h1.ID(), Addrs: h1.Addrs()}))
+}
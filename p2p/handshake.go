@@ -0,0 +1,82 @@
+package p2p
+
+import (
+	"sync"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/peer"
+	ma "github.com/multiformats/go-multiaddr"
+
+	"github.com/spacemeshos/go-spacemesh/log"
+	p2pmetrics "github.com/spacemeshos/go-spacemesh/p2p/metrics"
+)
+
+const (
+	// maxPendingHandshakes is the number of handshakes the timer tracks before it evicts abandoned handshakes.
+	maxPendingHandshakes = 10000
+	// abandonedHandshake is the age of a handshake after which it is assumed to have failed.
+	abandonedHandshake = time.Minute
+)
+
+// handshakeTimer measures the time from accepting or dialing a connection until the connection is
+// secured and the remote peer is authenticated.
+type handshakeTimer struct {
+	// observe is called with the duration of every completed handshake.
+	observe func(peer.ID, network.Direction, time.Duration)
+
+	mu      sync.Mutex
+	pending map[string]time.Time
+}
+
+func newHandshakeTimer(logger log.Log) *handshakeTimer {
+	return &handshakeTimer{
+		observe: func(id peer.ID, dir network.Direction, duration time.Duration) {
+			logger.With().Debug("handshake completed",
+				log.Stringer("peer", id),
+				log.Stringer("direction", dir),
+				log.Duration("duration", duration),
+			)
+			p2pmetrics.HandshakeDuration.WithLabelValues(dir.String()).Observe(duration.Seconds())
+		},
+		pending: map[string]time.Time{},
+	}
+}
+
+// inbound handshakes are keyed by the remote address, the peer is not known until the connection is secured.
+func handshakeKey(dir network.Direction, id peer.ID, remote ma.Multiaddr) string {
+	if dir == network.DirInbound {
+		return remote.String()
+	}
+	return id.String() + remote.String()
+}
+
+func (t *handshakeTimer) start(dir network.Direction, id peer.ID, remote ma.Multiaddr) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	now := time.Now()
+	if len(t.pending) >= maxPendingHandshakes {
+		t.evictAbandoned(now)
+	}
+	t.pending[handshakeKey(dir, id, remote)] = now
+}
+
+func (t *handshakeTimer) secured(dir network.Direction, id peer.ID, remote ma.Multiaddr) {
+	key := handshakeKey(dir, id, remote)
+	t.mu.Lock()
+	started, exist := t.pending[key]
+	delete(t.pending, key)
+	t.mu.Unlock()
+	if exist {
+		t.observe(id, dir, time.Since(started))
+	}
+}
+
+// evictAbandoned removes handshakes that didn't complete within abandonedHandshake.
+func (t *handshakeTimer) evictAbandoned(now time.Time) {
+	for key, started := range t.pending {
+		if now.Sub(started) > abandonedHandshake {
+			delete(t.pending, key)
+		}
+	}
+}
@@ -0,0 +1,81 @@
+package p2p
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/peer"
+	ma "github.com/multiformats/go-multiaddr"
+	"github.com/stretchr/testify/require"
+
+	"github.com/spacemeshos/go-spacemesh/log/logtest"
+)
+
+type handshakeRecord struct {
+	id       peer.ID
+	dir      network.Direction
+	duration time.Duration
+}
+
+type handshakeRecorder struct {
+	mu      sync.Mutex
+	records []handshakeRecord
+}
+
+func (r *handshakeRecorder) observe(id peer.ID, dir network.Direction, duration time.Duration) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.records = append(r.records, handshakeRecord{id: id, dir: dir, duration: duration})
+}
+
+func (r *handshakeRecorder) get() []handshakeRecord {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]handshakeRecord(nil), r.records...)
+}
+
+func TestHandshakeTimer_EvictAbandoned(t *testing.T) {
+	timer := newHandshakeTimer(logtest.New(t))
+	rec := &handshakeRecorder{}
+	timer.observe = rec.observe
+	abandoned := ma.StringCast("/ip4/1.2.3.4/tcp/1111")
+	timer.start(network.DirInbound, "", abandoned)
+	timer.pending[abandoned.String()] = time.Now().Add(-2 * abandonedHandshake)
+	for i := 0; i < maxPendingHandshakes; i++ {
+		timer.pending[string(rune(i))] = time.Now()
+	}
+	timer.start(network.DirInbound, "", ma.StringCast("/ip4/1.2.3.5/tcp/1111"))
+	require.Len(t, timer.pending, maxPendingHandshakes+1)
+
+	timer.secured(network.DirInbound, "peer", abandoned)
+	require.Empty(t, rec.get())
+}
+
+func TestHandshakeDuration(t *testing.T) {
+	newHost := func(t *testing.T) (*Host, *handshakeRecorder) {
+		h := newTestHost(t, testConfig(t))
+		rec := &handshakeRecorder{}
+		h.gater.handshakes.observe = rec.observe
+		return h, rec
+	}
+	h1, rec1 := newHost(t)
+	h2, rec2 := newHost(t)
+
+	require.NoError(t, h1.Connect(context.Background(), peer.AddrInfo{ID: h2.ID(), Addrs: h2.Addrs()}))
+	outbound := rec1.get()
+	require.Len(t, outbound, 1)
+	require.Equal(t, h2.ID(), outbound[0].id)
+	require.Equal(t, network.DirOutbound, outbound[0].dir)
+	require.Positive(t, outbound[0].duration)
+
+	require.Eventually(t, func() bool { return len(rec2.get()) == 1 }, time.Second, 10*time.Millisecond)
+	inbound := rec2.get()[0]
+	require.Equal(t, h1.ID(), inbound.id)
+	require.Equal(t, network.DirInbound, inbound.dir)
+	require.Positive(t, inbound.duration)
+	require.Empty(t, h1.gater.handshakes.pending)
+	require.Empty(t, h2.gater.handshakes.pending)
+}
@@ -3,6 +3,7 @@ package p2p
 import (
 	"context"
 	"fmt"
+	"net"
 	"time"
 
 	lp2plog "github.com/ipfs/go-log/v2"
@@ -16,9 +17,11 @@ import (
 	rcmgrObs "github.com/libp2p/go-libp2p/p2p/host/resource-manager/obs"
 	"github.com/libp2p/go-libp2p/p2p/muxer/yamux"
 	"github.com/libp2p/go-libp2p/p2p/net/connmgr"
+	"github.com/libp2p/go-libp2p/p2p/net/swarm"
 	tptu "github.com/libp2p/go-libp2p/p2p/net/upgrader"
 	"github.com/libp2p/go-libp2p/p2p/security/noise"
 	"github.com/libp2p/go-libp2p/p2p/transport/tcp"
+	madns "github.com/multiformats/go-multiaddr-dns"
 	"github.com/prometheus/client_golang/prometheus"
 	"go.uber.org/zap"
 
@@ -37,6 +40,10 @@ func DefaultConfig() Config {
 		GracePeersShutdown: 30 * time.Second,
 		MaxMessageSize:     2 << 20,
 		AcceptQueue:        tptu.AcceptQueueLength,
+		AcceptBurst:        10,
+		PeerOutboundQueue:  8192,
+		MaxKnownPeers:      50000,
+		DNSCacheTTL:        5 * time.Minute,
 	}
 }
 
@@ -59,6 +66,36 @@ type Config struct {
 	AdvertiseAddress string   `mapstructure:"advertise-address"`
 	AcceptQueue      int      `mapstructure:"p2p-accept-queue"`
 	Metrics          bool     `mapstructure:"p2p-metrics"`
+	// AllowCIDRs if not empty only connections with ip addresses in these networks are accepted and dialed.
+	AllowCIDRs []string `mapstructure:"allow-cidrs"`
+	// DenyCIDRs connections with ip addresses in these networks are never accepted nor dialed.
+	DenyCIDRs []string `mapstructure:"deny-cidrs"`
+	// AcceptRate is the number of inbound connections per second accepted from a single ip address.
+	// 0 disables the limit.
+	AcceptRate float64 `mapstructure:"p2p-accept-rate"`
+	// AcceptBurst is the number of inbound connections from a single ip address accepted in a burst.
+	AcceptBurst int `mapstructure:"p2p-accept-burst"`
+	// BootstrapJitter is the maximum random delay before the node starts dialing bootnodes and known peers.
+	// 0 disables the delay.
+	BootstrapJitter time.Duration `mapstructure:"p2p-bootstrap-jitter"`
+	// PeerOutboundQueue is the number of gossip messages queued for each peer.
+	// Messages to a peer with a full queue are dropped.
+	PeerOutboundQueue int `mapstructure:"p2p-peer-outbound-queue"`
+	// MaxKnownPeers is the maximum number of peer addresses kept by discovery.
+	// When it is reached stale addresses are evicted to make room for new ones.
+	MaxKnownPeers int `mapstructure:"p2p-max-known-peers"`
+	// DialBackoff is how long an address is not dialed again after a dial to it failed.
+	// The backoff grows with repeated failures and is cleared when a connection with the peer is established.
+	// 0 keeps the libp2p default of 5s.
+	// libp2p has no per-host backoff, so the value applies to all hosts of the process.
+	DialBackoff time.Duration `mapstructure:"p2p-dial-backoff"`
+	// DNSCacheTTL is how long the resolved ip addresses of a dns address are reused before it is resolved again.
+	// 0 resolves the address on every dial.
+	DNSCacheTTL time.Duration `mapstructure:"p2p-dns-cache-ttl"`
+	// KeepAliveInterval is how long a connection may be idle before a keep-alive ping is sent on it.
+	// The ping refreshes the NAT mappings of the connection, a connection that doesn't answer it is closed.
+	// 0 keeps the yamux default of 30s.
+	KeepAliveInterval time.Duration `mapstructure:"p2p-keep-alive-interval"`
 }
 
 // New initializes libp2p host configured for spacemesh.
@@ -74,7 +111,27 @@ func New(_ context.Context, logger log.Log, cfg Config, prologue []byte, opts ..
 	if err != nil {
 		return nil, fmt.Errorf("p2p create conn mgr: %w", err)
 	}
-	streamer := *yamux.DefaultTransport
+	gater, err := newAddressGater(cfg.AllowCIDRs, cfg.DenyCIDRs)
+	if err != nil {
+		return nil, fmt.Errorf("p2p create conn gater: %w", err)
+	}
+	if cfg.AcceptRate > 0 {
+		gater.limiter = newAcceptLimiter(cfg.AcceptRate, cfg.AcceptBurst)
+	}
+	gater.handshakes = newHandshakeTimer(logger)
+	// the options are applied to the upgraded host as well, only the resolver is needed before it is created
+	var pre Host
+	for _, opt := range opts {
+		opt(&pre)
+	}
+	if pre.resolver == nil {
+		pre.resolver = net.DefaultResolver
+	}
+	resolver, err := madns.NewResolver(madns.WithDefaultResolver(newCachingResolver(pre.resolver, cfg.DNSCacheTTL)))
+	if err != nil {
+		return nil, fmt.Errorf("p2p create resolver: %w", err)
+	}
+	streamer := newMuxer(cfg)
 	ps, err := pstoremem.NewPeerstore()
 	if err != nil {
 		return nil, fmt.Errorf("can't create peer store: %w", err)
@@ -102,11 +159,13 @@ func New(_ context.Context, logger log.Log, cfg Config, prologue []byte, opts ..
 			}
 			return tp.WithSessionOptions(noise.Prologue(prologue))
 		}),
-		libp2p.Muxer("/yamux/1.0.0", &streamer),
+		libp2p.Muxer("/yamux/1.0.0", streamer),
 
 		libp2p.ConnectionManager(cm),
+		libp2p.ConnectionGater(gater),
 		libp2p.Peerstore(ps),
 		libp2p.BandwidthReporter(p2pmetrics.NewBandwidthCollector()),
+		libp2p.MultiaddrResolver(resolver),
 	}
 	if cfg.Metrics {
 		lopts = append(lopts, setupResourcesManager)
@@ -117,6 +176,13 @@ func New(_ context.Context, logger log.Log, cfg Config, prologue []byte, opts ..
 	if cfg.AcceptQueue != 0 {
 		tptu.AcceptQueueLength = cfg.AcceptQueue
 	}
+	if cfg.DialBackoff != 0 {
+		// the backoff is a global of the libp2p swarm, it is shared by all hosts of the process
+		swarm.BackoffBase = cfg.DialBackoff
+		if swarm.BackoffMax < cfg.DialBackoff {
+			swarm.BackoffMax = cfg.DialBackoff
+		}
+	}
 	h, err := libp2p.New(lopts...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to initialize libp2p host: %w", err)
@@ -126,10 +192,18 @@ func New(_ context.Context, logger log.Log, cfg Config, prologue []byte, opts ..
 	logger.Zap().Info("local node identity", zap.Stringer("identity", h.ID()))
 	// TODO(dshulyak) this is small mess. refactor to avoid this patching
 	// both New and Upgrade should use options.
-	opts = append(opts, WithConfig(cfg), WithLog(logger))
+	opts = append(opts, WithConfig(cfg), WithLog(logger), withAddressGater(gater))
 	return Upgrade(h, opts...)
 }
 
+func newMuxer(cfg Config) *yamux.Transport {
+	streamer := *yamux.DefaultTransport
+	if cfg.KeepAliveInterval != 0 {
+		streamer.KeepAliveInterval = cfg.KeepAliveInterval
+	}
+	return &streamer
+}
+
 func setupResourcesManager(cfg *libp2p.Config) error {
 	rcmgrObs.MustRegisterWith(prometheus.DefaultRegisterer)
 	str, err := rcmgrObs.NewStatsTraceReporter()
@@ -2,14 +2,37 @@ package p2p
 
 import (
 	"context"
+	"encoding/binary"
+	"errors"
+	"io"
+	"net"
 	"testing"
+	"time"
 
 	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/libp2p/go-libp2p/p2p/net/swarm"
 	"github.com/stretchr/testify/require"
 
 	"github.com/spacemeshos/go-spacemesh/log/logtest"
 )
 
+// testConfig returns the default config with a temporary data directory,
+// the host listens on a random port of the loopback interface.
+func testConfig(tb testing.TB) Config {
+	cfg := DefaultConfig()
+	cfg.DataDir = tb.TempDir()
+	cfg.Listen = "/ip4/127.0.0.1/tcp/0"
+	return cfg
+}
+
+// newTestHost starts a host that is closed when the test finishes.
+func newTestHost(tb testing.TB, cfg Config, opts ...Opt) *Host {
+	h, err := New(context.Background(), logtest.New(tb), cfg, []byte("red"), opts...)
+	require.NoError(tb, err)
+	tb.Cleanup(func() { h.Close() })
+	return h
+}
+
 func TestPrologue(t *testing.T) {
 	cfg1 := DefaultConfig()
 	cfg1.DataDir = t.TempDir()
@@ -28,3 +51,61 @@ func TestPrologue(t *testing.T) {
 	})
 	require.ErrorContains(t, err, "failed to negotiate security protocol")
 }
+
+func TestDialBackoff(t *testing.T) {
+	base, max := swarm.BackoffBase, swarm.BackoffMax
+	t.Cleanup(func() {
+		swarm.BackoffBase, swarm.BackoffMax = base, max
+	})
+	cfg := testConfig(t)
+	cfg.DialBackoff = 200 * time.Millisecond
+	h1 := newTestHost(t, cfg)
+	h2 := newTestHost(t, testConfig(t))
+	info := peer.AddrInfo{ID: h2.ID(), Addrs: h2.Addrs()}
+	require.NoError(t, h2.Close())
+
+	backoff := func(err error) bool {
+		var derr *swarm.DialError
+		require.ErrorAs(t, err, &derr)
+		require.Len(t, derr.DialErrors, 1)
+		return errors.Is(derr.DialErrors[0].Cause, swarm.ErrDialBackoff)
+	}
+	require.False(t, backoff(h1.Connect(context.Background(), info)))
+	// the address failed within the backoff, it is not dialed
+	require.True(t, backoff(h1.Connect(context.Background(), info)))
+
+	time.Sleep(2 * cfg.DialBackoff)
+	require.False(t, backoff(h1.Connect(context.Background(), info)))
+}
+
+func TestKeepAlive(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.KeepAliveInterval = 100 * time.Millisecond
+	local, remote := net.Pipe()
+	t.Cleanup(func() {
+		local.Close()
+		remote.Close()
+	})
+	conn, err := newMuxer(cfg).NewConn(local, false, nil)
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+
+	require.NoError(t, remote.SetDeadline(time.Now().Add(5*time.Second)))
+	// yamux frame header: version, type, flags, stream id and length (the opaque value of a ping)
+	hdr := make([]byte, 12)
+	// the session measures the rtt with a ping when it starts
+	_, err = io.ReadFull(remote, hdr)
+	require.NoError(t, err)
+	require.Equal(t, byte(2), hdr[1], "ping type")
+	pong := append([]byte{0, 2, 0, 2, 0, 0, 0, 0}, hdr[8:12]...)
+	_, err = remote.Write(pong)
+	require.NoError(t, err)
+
+	idle := time.Now()
+	_, err = io.ReadFull(remote, hdr)
+	require.NoError(t, err)
+	require.GreaterOrEqual(t, time.Since(idle), cfg.KeepAliveInterval)
+	require.Equal(t, byte(0), hdr[0], "version")
+	require.Equal(t, byte(2), hdr[1], "ping type")
+	require.Equal(t, uint16(1), binary.BigEndian.Uint16(hdr[2:4]), "syn flag")
+}
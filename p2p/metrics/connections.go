@@ -5,6 +5,7 @@ import (
 
 	"github.com/libp2p/go-libp2p/core/network"
 	ma "github.com/multiformats/go-multiaddr"
+	"github.com/prometheus/client_golang/prometheus"
 
 	"github.com/spacemeshos/go-spacemesh/metrics"
 )
@@ -32,6 +33,15 @@ var (
 		"Connections dropped due to ErrValidationReject result",
 		nil,
 	).WithLabelValues()
+
+	// HandshakeDuration is the time from accepting or dialing a connection until the peer is authenticated.
+	HandshakeDuration = metrics.NewHistogramWithBuckets(
+		"handshake_duration",
+		subsystem,
+		"Duration of the security handshake of inbound and outbound connections (seconds)",
+		[]string{"direction"},
+		prometheus.ExponentialBuckets(0.001, 2, 16),
+	)
 )
 
 // ConnectionsMeeter stores the number of connections for node.
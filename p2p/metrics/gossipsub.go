@@ -49,15 +49,23 @@ var (
 		"Total amount of received messages",
 		[]string{"protocol"},
 	)
+	droppedRPCCount = metrics.NewCounter(
+		"dropped_rpc_count",
+		subsystem,
+		"Total number of outbound rpcs dropped because the queue of the peer was full",
+		nil,
+	)
 )
 
 // GossipCollector pubsub.RawTracer implementation
 // total number of peers
-// number of peers per each gossip protocol.
+// number of peers per each gossip protocol
+// number of outbound rpcs dropped for each peer.
 type GossipCollector struct {
 	peers struct {
 		sync.Mutex
-		m map[peer.ID]protocol.ID
+		m       map[peer.ID]protocol.ID
+		dropped map[peer.ID]uint64
 	}
 }
 
@@ -66,13 +74,22 @@ func NewGoSIPCollector() *GossipCollector {
 	return &GossipCollector{
 		peers: struct {
 			sync.Mutex
-			m map[peer.ID]protocol.ID
+			m       map[peer.ID]protocol.ID
+			dropped map[peer.ID]uint64
 		}{
-			m: make(map[peer.ID]protocol.ID),
+			m:       make(map[peer.ID]protocol.ID),
+			dropped: make(map[peer.ID]uint64),
 		},
 	}
 }
 
+// Dropped returns the number of outbound rpcs dropped for the connected peer.
+func (g *GossipCollector) Dropped(id peer.ID) uint64 {
+	g.peers.Lock()
+	defer g.peers.Unlock()
+	return g.peers.dropped[id]
+}
+
 // AddPeer is invoked when a new peer is added.
 func (g *GossipCollector) AddPeer(id peer.ID, proto protocol.ID) {
 	g.peers.Lock()
@@ -88,6 +105,7 @@ func (g *GossipCollector) RemovePeer(id peer.ID) {
 	g.peers.Lock()
 	proto := g.peers.m[id]
 	delete(g.peers.m, id)
+	delete(g.peers.dropped, id)
 	g.peers.Unlock()
 
 	peersPerProtocol.WithLabelValues(string(proto)).Dec()
@@ -147,7 +165,13 @@ func (g *GossipCollector) RecvRPC(*pubsub.RPC) {}
 func (g *GossipCollector) SendRPC(*pubsub.RPC, peer.ID) {}
 
 // DropRPC is invoked when an outbound RPC is dropped, typically because of a queue full.
-func (g *GossipCollector) DropRPC(*pubsub.RPC, peer.ID) {}
+func (g *GossipCollector) DropRPC(_ *pubsub.RPC, id peer.ID) {
+	g.peers.Lock()
+	g.peers.dropped[id]++
+	g.peers.Unlock()
+
+	droppedRPCCount.WithLabelValues().Inc()
+}
 
 // UndeliverableMessage is invoked when the consumer of Subscribe is not reading messages fast enough and
 // the pressure release mechanism trigger, dropping messages.
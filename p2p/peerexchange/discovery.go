@@ -6,6 +6,7 @@ import (
 	"errors"
 	"fmt"
 	"math"
+	"math/rand"
 	"net"
 	"os"
 	"path/filepath"
@@ -36,6 +37,11 @@ type Config struct {
 	AdvertiseAddress     string // Address to advertise to a peers.
 	MinPeers             int
 	FastCrawl, SlowCrawl time.Duration
+	// InitialJitter is the maximum random delay before the first crawl.
+	// It spreads the bootstrap dials of nodes that are restarted at the same time.
+	InitialJitter time.Duration
+	// MaxKnownPeers is the maximum number of addresses in the address book, the book default if 0.
+	MaxKnownPeers int
 }
 
 // Discovery is struct that holds the protocol components, the protocol definition, the addr book data structure and more.
@@ -50,6 +56,7 @@ type Discovery struct {
 
 	book  *book.Book
 	crawl *crawler
+	rng   *rand.Rand
 
 	collector *collector
 }
@@ -57,13 +64,18 @@ type Discovery struct {
 // New creates a Discovery instance.
 func New(logger log.Log, h host.Host, config Config) (*Discovery, error) {
 	ctx, cancel := context.WithCancel(context.Background())
+	var opts []book.Opt
+	if config.MaxKnownPeers > 0 {
+		opts = append(opts, book.WithLimit(config.MaxKnownPeers))
+	}
 	d := &Discovery{
 		cfg:    config,
 		logger: logger,
 		host:   h,
 		ctx:    ctx,
 		cancel: cancel,
-		book:   book.New(),
+		book:   book.New(opts...),
+		rng:    rand.New(rand.NewSource(time.Now().UnixNano())),
 	}
 	d.collector = newCollector(d.book)
 	var advertise ma.Multiaddr
@@ -174,6 +186,12 @@ func (d *Discovery) watchPortChanges(ctx context.Context, protocol *peerExchange
 	return nil
 }
 
+// Forget removes the peer from the address book. It is dropped from the persisted
+// book on the next checkpoint.
+func (d *Discovery) Forget(id peer.ID) {
+	d.book.Remove(id.String())
+}
+
 // Stop stops the discovery service.
 func (d *Discovery) Stop() {
 	d.collector.Stop()
@@ -186,6 +204,13 @@ func (d *Discovery) scanPeers(ctx context.Context) {
 	period := d.cfg.FastCrawl
 	concurrent := 5
 	d.eg.Go(func() error {
+		if delay := d.initialDelay(); delay > 0 {
+			select {
+			case <-ctx.Done():
+				return nil
+			case <-time.After(delay):
+			}
+		}
 		var prev time.Time
 		for {
 			select {
@@ -214,6 +239,14 @@ func (d *Discovery) scanPeers(ctx context.Context) {
 	})
 }
 
+// initialDelay returns a random delay in [0, InitialJitter).
+func (d *Discovery) initialDelay() time.Duration {
+	if d.cfg.InitialJitter <= 0 {
+		return 0
+	}
+	return time.Duration(d.rng.Int63n(int64(d.cfg.InitialJitter)))
+}
+
 // AdvertisedAddress returns advertised address.
 func (d *Discovery) AdvertisedAddress() ma.Multiaddr {
 	return d.crawl.disc.AdvertisedAddress()
@@ -2,6 +2,7 @@ package peerexchange
 
 import (
 	"fmt"
+	"math/rand"
 	"sync"
 	"testing"
 	"time"
@@ -53,6 +54,42 @@ func TestDiscovery_CrawlMesh(t *testing.T) {
 	}, 3*time.Second, 200*time.Millisecond)
 }
 
+func TestDiscovery_InitialJitter(t *testing.T) {
+	mesh, err := mocknet.FullMeshLinked(2)
+	require.NoError(t, err)
+	h, bootnode := mesh.Hosts()[0], mesh.Hosts()[1]
+	p2p, err := ma.NewComponent("p2p", bootnode.ID().String())
+	require.NoError(t, err)
+	// the bootnode doesn't know any peers and never dials
+	served, err := New(logtest.New(t), bootnode, Config{FastCrawl: time.Second, SlowCrawl: 10 * time.Second})
+	require.NoError(t, err)
+	t.Cleanup(served.Stop)
+
+	cfg := Config{
+		FastCrawl:     time.Second,
+		SlowCrawl:     10 * time.Second,
+		MinPeers:      1,
+		Bootnodes:     []string{bootnode.Addrs()[0].Encapsulate(p2p).String()},
+		InitialJitter: 2 * time.Second,
+	}
+	instance, err := New(logtest.New(t), h, cfg)
+	require.NoError(t, err)
+	const seed = 101
+	instance.rng = rand.New(rand.NewSource(seed))
+	delay := time.Duration(rand.New(rand.NewSource(seed)).Int63n(int64(cfg.InitialJitter)))
+	require.Less(t, delay, cfg.InitialJitter)
+
+	start := time.Now()
+	instance.StartScan()
+	t.Cleanup(instance.Stop)
+	require.Eventually(t, func() bool {
+		return len(h.Network().Conns()) > 0
+	}, 5*time.Second, 10*time.Millisecond)
+	elapsed := time.Since(start)
+	require.GreaterOrEqual(t, elapsed, delay)
+	require.Less(t, elapsed, cfg.InitialJitter+peerPollPeriod+time.Second)
+}
+
 //go:generate mockgen -package=mocks -destination=./mocks/mocks.go -source=./discovery_test.go
 
 // AddrProvider provider for multiaddrs.
@@ -78,9 +78,11 @@ const (
 	MalfeasanceProof = "mp1"
 )
 
+const defaultQueueSize = 8192
+
 // DefaultConfig for PubSub.
 func DefaultConfig() Config {
-	return Config{Flood: true}
+	return Config{Flood: true, QueueSize: defaultQueueSize}
 }
 
 // Config for PubSub.
@@ -88,21 +90,27 @@ type Config struct {
 	Flood          bool
 	IsBootnode     bool
 	MaxMessageSize int
+	// QueueSize is the number of outbound messages queued for each peer.
+	// When the queue of a slow peer is full, messages to that peer are dropped
+	// without affecting other peers.
+	QueueSize int
 }
 
 // New creates PubSub instance.
 func New(ctx context.Context, logger log.Log, h host.Host, cfg Config) (*PubSub, error) {
 	// TODO(dshulyak) refactor code to accept options
-	opts := getOptions(cfg)
+	collector := p2pmetrics.NewGoSIPCollector()
+	opts := getOptions(cfg, collector)
 	ps, err := pubsub.NewGossipSub(ctx, h, opts...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to initialize gossipsub instance: %w", err)
 	}
 	return &PubSub{
-		logger: logger,
-		pubsub: ps,
-		topics: map[string]*pubsub.Topic{},
-		host:   h,
+		logger:    logger,
+		pubsub:    ps,
+		topics:    map[string]*pubsub.Topic{},
+		host:      h,
+		collector: collector,
 	}, nil
 }
 
@@ -145,6 +153,30 @@ func ChainGossipHandler(handlers ...GossipHandler) GossipHandler {
 	}
 }
 
+// OutboundInterceptor is called with every message before it is published to the topic.
+// It returns the message to publish, which may be modified, or nil to drop the message.
+// It may block to delay the message.
+type OutboundInterceptor = func(ctx context.Context, topic string, msg []byte) []byte
+
+// InterceptPublisher wraps a publisher to pass every outbound message through the interceptor.
+// It is meant for tests that need to inject faults, such as delayed or dropped messages.
+func InterceptPublisher(pub Publisher, intercept OutboundInterceptor) Publisher {
+	return &interceptedPublisher{Publisher: pub, intercept: intercept}
+}
+
+type interceptedPublisher struct {
+	Publisher
+	intercept OutboundInterceptor
+}
+
+func (p *interceptedPublisher) Publish(ctx context.Context, topic string, msg []byte) error {
+	msg = p.intercept(ctx, topic, msg)
+	if msg == nil {
+		return nil
+	}
+	return p.Publisher.Publish(ctx, topic, msg)
+}
+
 // DropPeerValidationReject wraps a gossip handler to provide a handler that drops a
 // peer if the wrapped handler returns ErrValidationReject.
 func DropPeerOnValidationReject(handler GossipHandler, h host.Host, logger log.Log) GossipHandler {
@@ -173,16 +205,20 @@ func msgID(msg *pubsubpb.Message) string {
 	return string(hasher.Sum(nil))
 }
 
-func getOptions(cfg Config) []pubsub.Option {
+func getOptions(cfg Config, collector *p2pmetrics.GossipCollector) []pubsub.Option {
+	queueSize := cfg.QueueSize
+	if queueSize == 0 {
+		queueSize = defaultQueueSize
+	}
 	options := []pubsub.Option{
 		// Gossipsubv1.1 configuration
 		pubsub.WithFloodPublish(cfg.Flood),
 		pubsub.WithMessageIdFn(msgID),
 		pubsub.WithNoAuthor(),
 		pubsub.WithMessageSignaturePolicy(pubsub.StrictNoSign),
-		pubsub.WithPeerOutboundQueueSize(8192),
+		pubsub.WithPeerOutboundQueueSize(queueSize),
 		pubsub.WithValidateQueueSize(8192),
-		pubsub.WithRawTracer(p2pmetrics.NewGoSIPCollector()),
+		pubsub.WithRawTracer(collector),
 		pubsub.WithPeerScore(
 			&pubsub.PeerScoreParams{
 				AppSpecificScore: func(p peer.ID) float64 {
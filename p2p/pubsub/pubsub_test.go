@@ -49,3 +49,105 @@ func TestGossip(t *testing.T) {
 	}
 	require.Eventually(t, func() bool { return len(received) == count }, 5*time.Second, 10*time.Millisecond)
 }
+
+func TestSlowPeerQueue(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	mesh, err := mocknet.FullMeshLinked(3)
+	require.NoError(t, err)
+	t.Cleanup(func() { mesh.Close() })
+	topic := "test"
+	const (
+		queue = 4
+		count = 100
+	)
+	pubsubs := []*PubSub{}
+	received := make([]chan []byte, 3)
+	for i, h := range mesh.Hosts() {
+		ps, err := New(ctx, logtest.New(t), h, Config{Flood: true, IsBootnode: true, QueueSize: queue})
+		require.NoError(t, err)
+		pubsubs = append(pubsubs, ps)
+		ch := make(chan []byte, count)
+		received[i] = ch
+		ps.Register(topic, func(ctx context.Context, pid peer.ID, msg []byte) error {
+			ch <- msg
+			return nil
+		})
+	}
+	require.NoError(t, mesh.ConnectAllButSelf())
+	require.Eventually(t, func() bool {
+		for _, ps := range pubsubs {
+			if len(ps.ProtocolPeers(topic)) != len(mesh.Hosts())-1 {
+				return false
+			}
+		}
+		return true
+	}, 5*time.Second, 10*time.Millisecond)
+
+	hosts := mesh.Hosts()
+	publisher, fast, slow := pubsubs[0], hosts[1].ID(), hosts[2].ID()
+	for _, link := range mesh.LinksBetweenPeers(hosts[0].ID(), slow) {
+		link.SetOptions(mocknet.LinkOptions{Latency: 10 * time.Second})
+	}
+	for i := 0; i < count; i++ {
+		require.NoError(t, publisher.Publish(ctx, topic, []byte{byte(i)}))
+		time.Sleep(time.Millisecond)
+	}
+
+	require.Eventually(t, func() bool { return len(received[1]) == count }, 5*time.Second, 10*time.Millisecond)
+	require.Zero(t, publisher.DroppedMessages(fast))
+	require.NotZero(t, publisher.DroppedMessages(slow))
+}
+
+func TestInterceptPublisher(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	mesh, err := mocknet.FullMeshLinked(2)
+	require.NoError(t, err)
+	t.Cleanup(func() { mesh.Close() })
+	const (
+		dropped = "dropped"
+		kept    = "kept"
+	)
+	pubsubs := []*PubSub{}
+	received := make(chan string, 10)
+	for _, h := range mesh.Hosts() {
+		h := h
+		ps, err := New(ctx, logtest.New(t), h, Config{Flood: true, IsBootnode: true})
+		require.NoError(t, err)
+		pubsubs = append(pubsubs, ps)
+		for _, topic := range []string{dropped, kept} {
+			topic := topic
+			ps.Register(topic, func(ctx context.Context, pid peer.ID, msg []byte) error {
+				if pid != h.ID() {
+					received <- topic
+				}
+				return nil
+			})
+		}
+	}
+	require.NoError(t, mesh.ConnectAllButSelf())
+	require.Eventually(t, func() bool {
+		for _, ps := range pubsubs {
+			if len(ps.ProtocolPeers(kept)) != 1 || len(ps.ProtocolPeers(dropped)) != 1 {
+				return false
+			}
+		}
+		return true
+	}, 5*time.Second, 10*time.Millisecond)
+
+	publisher := InterceptPublisher(pubsubs[0], func(_ context.Context, topic string, msg []byte) []byte {
+		if topic == dropped {
+			return nil
+		}
+		return msg
+	})
+	require.NoError(t, publisher.Publish(ctx, dropped, []byte("first")))
+	require.NoError(t, publisher.Publish(ctx, kept, []byte("second")))
+	require.Equal(t, kept, <-received)
+	select {
+	case topic := <-received:
+		require.Failf(t, "unexpected message", "topic %s", topic)
+	case <-time.After(100 * time.Millisecond):
+	}
+}
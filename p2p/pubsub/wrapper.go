@@ -21,10 +21,18 @@ type PubSub struct {
 	pubsub *pubsub.PubSub
 	host   host.Host
 
+	collector *metrics.GossipCollector
+
 	mu     sync.RWMutex
 	topics map[string]*pubsub.Topic
 }
 
+// DroppedMessages returns the number of outbound messages dropped for the connected peer
+// because its queue was full.
+func (ps *PubSub) DroppedMessages(id peer.ID) uint64 {
+	return ps.collector.Dropped(id)
+}
+
 // Register handler for topic.
 func (ps *PubSub) Register(topic string, handler GossipHandler) {
 	ps.mu.Lock()
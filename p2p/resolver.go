@@ -0,0 +1,80 @@
+package p2p
+
+import (
+	"context"
+	"net"
+	"sync"
+	"time"
+
+	madns "github.com/multiformats/go-multiaddr-dns"
+)
+
+// Resolver resolves the host names of dns addresses (e.g. /dns4/node.example.com/tcp/7513) before they are dialed.
+// net.Resolver implements it.
+type Resolver = madns.BasicResolver
+
+type cachedLookup[T any] struct {
+	result  []T
+	expires time.Time
+}
+
+// cachingResolver caches successful lookups of the wrapped resolver for ttl.
+// Failed lookups are not cached so that a peer is reachable as soon as its name resolves.
+type cachingResolver struct {
+	resolver Resolver
+	ttl      time.Duration
+	now      func() time.Time
+
+	mu  sync.Mutex
+	ips map[string]cachedLookup[net.IPAddr]
+	txt map[string]cachedLookup[string]
+}
+
+func newCachingResolver(resolver Resolver, ttl time.Duration) *cachingResolver {
+	return &cachingResolver{
+		resolver: resolver,
+		ttl:      ttl,
+		now:      time.Now,
+		ips:      map[string]cachedLookup[net.IPAddr]{},
+		txt:      map[string]cachedLookup[string]{},
+	}
+}
+
+// LookupIPAddr returns the ip addresses of the host.
+func (r *cachingResolver) LookupIPAddr(ctx context.Context, host string) ([]net.IPAddr, error) {
+	return lookup(r, r.ips, host, func() ([]net.IPAddr, error) {
+		return r.resolver.LookupIPAddr(ctx, host)
+	})
+}
+
+// LookupTXT returns the txt records of the name, they are used to resolve /dnsaddr addresses.
+func (r *cachingResolver) LookupTXT(ctx context.Context, name string) ([]string, error) {
+	return lookup(r, r.txt, name, func() ([]string, error) {
+		return r.resolver.LookupTXT(ctx, name)
+	})
+}
+
+func lookup[T any](r *cachingResolver, cache map[string]cachedLookup[T], name string, resolve func() ([]T, error)) ([]T, error) {
+	if r.ttl == 0 {
+		return resolve()
+	}
+	r.mu.Lock()
+	cached, ok := cache[name]
+	r.mu.Unlock()
+	if ok && r.now().Before(cached.expires) {
+		return cached.result, nil
+	}
+	result, err := resolve()
+	if err != nil {
+		return nil, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for name, cached := range cache {
+		if !r.now().Before(cached.expires) {
+			delete(cache, name)
+		}
+	}
+	cache[name] = cachedLookup[T]{result: result, expires: r.now().Add(r.ttl)}
+	return result, nil
+}
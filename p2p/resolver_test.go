@@ -0,0 +1,92 @@
+package p2p
+
+import (
+	"context"
+	"errors"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+	ma "github.com/multiformats/go-multiaddr"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeResolver struct {
+	mu      sync.Mutex
+	hosts   map[string]net.IP
+	lookups int
+}
+
+func (r *fakeResolver) LookupIPAddr(_ context.Context, host string) ([]net.IPAddr, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.lookups++
+	ip, ok := r.hosts[host]
+	if !ok {
+		return nil, &net.DNSError{Err: "no such host", Name: host, IsNotFound: true}
+	}
+	return []net.IPAddr{{IP: ip}}, nil
+}
+
+func (r *fakeResolver) LookupTXT(context.Context, string) ([]string, error) {
+	return nil, errors.New("not supported")
+}
+
+func (r *fakeResolver) getLookups() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.lookups
+}
+
+func TestCachingResolver(t *testing.T) {
+	fake := &fakeResolver{hosts: map[string]net.IP{"node.example": net.ParseIP("1.2.3.4")}}
+	r := newCachingResolver(fake, time.Minute)
+	now := time.Now()
+	r.now = func() time.Time { return now }
+
+	for i := 0; i < 2; i++ {
+		ips, err := r.LookupIPAddr(context.Background(), "node.example")
+		require.NoError(t, err)
+		require.Equal(t, []net.IPAddr{{IP: net.ParseIP("1.2.3.4")}}, ips)
+	}
+	require.Equal(t, 1, fake.getLookups())
+
+	// failed lookups are not cached
+	for i := 0; i < 2; i++ {
+		_, err := r.LookupIPAddr(context.Background(), "unknown.example")
+		require.Error(t, err)
+	}
+	require.Equal(t, 3, fake.getLookups())
+
+	// the address changed and the cached lookup expired
+	fake.hosts["node.example"] = net.ParseIP("1.2.3.5")
+	now = now.Add(time.Minute)
+	ips, err := r.LookupIPAddr(context.Background(), "node.example")
+	require.NoError(t, err)
+	require.Equal(t, []net.IPAddr{{IP: net.ParseIP("1.2.3.5")}}, ips)
+	require.Equal(t, 4, fake.getLookups())
+
+	uncached := newCachingResolver(fake, 0)
+	for i := 0; i < 2; i++ {
+		_, err := uncached.LookupIPAddr(context.Background(), "node.example")
+		require.NoError(t, err)
+	}
+	require.Equal(t, 6, fake.getLookups())
+}
+
+func TestDialHostname(t *testing.T) {
+	fake := &fakeResolver{hosts: map[string]net.IP{"node.example": net.ParseIP("127.0.0.1")}}
+	h1 := newTestHost(t, testConfig(t), WithResolver(fake))
+	h2 := newTestHost(t, testConfig(t))
+
+	require.Len(t, h2.Addrs(), 1)
+	port, err := h2.Addrs()[0].ValueForProtocol(ma.P_TCP)
+	require.NoError(t, err)
+	addr, err := ma.NewMultiaddr("/dns4/node.example/tcp/" + port)
+	require.NoError(t, err)
+	require.NoError(t, h1.Connect(context.Background(), peer.AddrInfo{ID: h2.ID(), Addrs: []ma.Multiaddr{addr}}))
+	require.NotEmpty(t, h1.Network().ConnsToPeer(h2.ID()))
+	require.NotZero(t, fake.getLookups())
+}
@@ -4,12 +4,16 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sort"
 	"sync"
 	"time"
 
 	"github.com/libp2p/go-libp2p/core/host"
 	"github.com/libp2p/go-libp2p/core/network"
 	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/libp2p/go-libp2p/core/protocol"
+	ma "github.com/multiformats/go-multiaddr"
+	manet "github.com/multiformats/go-multiaddr/net"
 
 	"github.com/spacemeshos/go-spacemesh/log"
 	"github.com/spacemeshos/go-spacemesh/p2p/peerexchange"
@@ -48,6 +52,30 @@ func WithNodeReporter(reporter func()) Opt {
 	}
 }
 
+// WithConnectionReporter updates reporter that is notified every time when
+// a connection is established. The direction tells if the connection was dialed
+// by the node or accepted from the remote peer.
+// The reporter is called synchronously by the libp2p notifier and must not block.
+func WithConnectionReporter(reporter func(peer.ID, network.Direction)) Opt {
+	return func(fh *Host) {
+		fh.connReporter = reporter
+	}
+}
+
+// WithResolver sets the resolver for the host names of dns addresses.
+// The default is the system resolver.
+func WithResolver(resolver Resolver) Opt {
+	return func(fh *Host) {
+		fh.resolver = resolver
+	}
+}
+
+func withAddressGater(gater *addressGater) Opt {
+	return func(fh *Host) {
+		fh.gater = gater
+	}
+}
+
 // Host is a conveniency wrapper for all p2p related functionality required to run
 // a full spacemesh node.
 type Host struct {
@@ -64,8 +92,11 @@ type Host struct {
 	*pubsub.PubSub
 
 	nodeReporter func()
+	connReporter func(peer.ID, network.Direction)
 
 	discovery *peerexchange.Discovery
+	gater     *addressGater
+	resolver  Resolver
 }
 
 // TODO(dshulyak) IsBootnode should be a configuration option.
@@ -102,6 +133,7 @@ func Upgrade(h host.Host, opts ...Opt) (*Host, error) {
 		Flood:          cfg.Flood,
 		IsBootnode:     bootnode,
 		MaxMessageSize: cfg.MaxMessageSize,
+		QueueSize:      cfg.PeerOutboundQueue,
 	}); err != nil {
 		return nil, fmt.Errorf("failed to initialize pubsub: %w", err)
 	}
@@ -112,6 +144,8 @@ func Upgrade(h host.Host, opts ...Opt) (*Host, error) {
 		MinPeers:         cfg.MinPeers,
 		SlowCrawl:        10 * time.Minute,
 		FastCrawl:        10 * time.Second,
+		InitialJitter:    cfg.BootstrapJitter,
+		MaxKnownPeers:    cfg.MaxKnownPeers,
 	}); err != nil {
 		return nil, fmt.Errorf("failed to initialize peerexchange discovery: %w", err)
 	}
@@ -125,9 +159,137 @@ func Upgrade(h host.Host, opts ...Opt) (*Host, error) {
 			},
 		})
 	}
+	if fh.connReporter != nil {
+		fh.Network().Notify(&network.NotifyBundle{
+			ConnectedF: func(_ network.Network, conn network.Conn) {
+				fh.connReporter(conn.RemotePeer(), conn.Stat().Direction)
+			},
+		})
+	}
 	return fh, nil
 }
 
+// UpdateAddressFilters replaces the lists of networks that are allowed and denied
+// for inbound and outbound connections. Existing connections are not affected.
+func (fh *Host) UpdateAddressFilters(allow, deny []string) error {
+	if fh.gater == nil {
+		return errors.New("address filters are not enabled on this host")
+	}
+	return fh.gater.update(allow, deny)
+}
+
+// AddListener starts accepting connections on addr in addition to the existing listeners.
+func (fh *Host) AddListener(addr string) error {
+	maddr, err := ma.NewMultiaddr(addr)
+	if err != nil {
+		return fmt.Errorf("parse listen address %s: %w", addr, err)
+	}
+	if err := fh.Network().Listen(maddr); err != nil {
+		return fmt.Errorf("listen on %s: %w", addr, err)
+	}
+	return nil
+}
+
+// RemoveListener stops accepting connections on addr. Connections that were accepted
+// on addr are not affected.
+// addr must match one of the listen addresses of the host, see Network().ListenAddresses().
+func (fh *Host) RemoveListener(addr string) error {
+	maddr, err := ma.NewMultiaddr(addr)
+	if err != nil {
+		return fmt.Errorf("parse listen address %s: %w", addr, err)
+	}
+	closer, ok := fh.Network().(interface{ ListenClose(...ma.Multiaddr) })
+	if !ok {
+		return errors.New("network doesn't support closing listeners")
+	}
+	for _, listening := range fh.Network().ListenAddresses() {
+		if listening.Equal(maddr) {
+			closer.ListenClose(maddr)
+			return nil
+		}
+	}
+	return fmt.Errorf("not listening on %s", addr)
+}
+
+// ForgetPeer closes all connections with the peer and removes everything the host
+// knows about it: addresses, keys and metadata in the peerstore and its entry in
+// the discovery address book.
+// The peer may be learned again from other peers or if it connects to the host.
+func (fh *Host) ForgetPeer(id peer.ID) error {
+	if err := fh.Network().ClosePeer(id); err != nil {
+		return fmt.Errorf("close connections with %s: %w", id, err)
+	}
+	fh.Peerstore().ClearAddrs(id)
+	fh.Peerstore().RemovePeer(id)
+	fh.discovery.Forget(id)
+	return nil
+}
+
+// Probe checks that addr accepts TCP connections by dialing it and closing the connection
+// right away. No handshake is performed and nothing is recorded about the remote peer, so
+// it can be used to validate addresses before they are added to the peerstore.
+// addr may include the /p2p/<id> suffix, it is ignored.
+func (fh *Host) Probe(ctx context.Context, addr string, timeout time.Duration) error {
+	maddr, err := ma.NewMultiaddr(addr)
+	if err != nil {
+		return fmt.Errorf("parse address %s: %w", addr, err)
+	}
+	maddr, _ = peer.SplitAddr(maddr)
+	if maddr == nil {
+		return fmt.Errorf("address %s has no transport", addr)
+	}
+	if fh.gater != nil && !fh.gater.allowed(maddr) {
+		return fmt.Errorf("address %s is denied by the address filters", addr)
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	var dialer manet.Dialer
+	conn, err := dialer.DialContext(ctx, maddr)
+	if err != nil {
+		return fmt.Errorf("dial %s: %w", addr, err)
+	}
+	return conn.Close()
+}
+
+// ConnectionInfo describes an established connection.
+type ConnectionInfo struct {
+	ID        string
+	Peer      peer.ID
+	Security  protocol.ID // for example /noise
+	Muxer     protocol.ID // for example /yamux/1.0.0
+	Direction network.Direction
+	Opened    time.Time
+	Streams   int // the number of streams currently open
+}
+
+// Age returns how long the connection has been open.
+func (ci ConnectionInfo) Age() time.Duration {
+	return time.Since(ci.Opened)
+}
+
+// Connections returns the established connections, ordered by the time they were opened.
+func (fh *Host) Connections() []ConnectionInfo {
+	conns := fh.Network().Conns()
+	rst := make([]ConnectionInfo, 0, len(conns))
+	for _, conn := range conns {
+		state := conn.ConnState()
+		stat := conn.Stat()
+		rst = append(rst, ConnectionInfo{
+			ID:        conn.ID(),
+			Peer:      conn.RemotePeer(),
+			Security:  state.Security,
+			Muxer:     state.StreamMultiplexer,
+			Direction: stat.Direction,
+			Opened:    stat.Opened,
+			Streams:   stat.NumStreams,
+		})
+	}
+	sort.Slice(rst, func(i, j int) bool {
+		return rst[i].Opened.Before(rst[j].Opened)
+	})
+	return rst
+}
+
 // GetPeers returns connected peers.
 func (fh *Host) GetPeers() []Peer {
 	return fh.Host.Network().Peers()
@@ -1,14 +1,19 @@
 package p2p
 
 import (
+	"context"
 	"fmt"
 	"sync/atomic"
 	"testing"
 	"time"
 
 	"github.com/libp2p/go-libp2p"
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/libp2p/go-libp2p/core/protocol"
 	"github.com/libp2p/go-libp2p/p2p/net/connmgr"
 	mocknet "github.com/libp2p/go-libp2p/p2p/net/mock"
+	ma "github.com/multiformats/go-multiaddr"
 	"github.com/stretchr/testify/require"
 )
 
@@ -72,3 +77,130 @@ func TestConnectionsNotifier(t *testing.T) {
 		return counter[0].Load() >= 3 && counter[1].Load() >= 2
 	}, time.Second, 10*time.Millisecond)
 }
+
+func TestConnectionReporter(t *testing.T) {
+	type connection struct {
+		peer      peer.ID
+		direction network.Direction
+	}
+	newHost := func(t *testing.T) (*Host, chan connection) {
+		established := make(chan connection, 4)
+		h := newTestHost(t, testConfig(t), WithConnectionReporter(func(id peer.ID, dir network.Direction) {
+			established <- connection{peer: id, direction: dir}
+		}))
+		return h, established
+	}
+	h1, dialed := newHost(t)
+	h2, accepted := newHost(t)
+
+	require.NoError(t, h1.Connect(context.Background(), peer.AddrInfo{ID: h2.ID(), Addrs: h2.Addrs()}))
+	for _, tc := range []struct {
+		established chan connection
+		expected    connection
+	}{
+		{established: dialed, expected: connection{peer: h2.ID(), direction: network.DirOutbound}},
+		{established: accepted, expected: connection{peer: h1.ID(), direction: network.DirInbound}},
+	} {
+		select {
+		case conn := <-tc.established:
+			require.Equal(t, tc.expected, conn)
+		case <-time.After(time.Second):
+			require.FailNow(t, "connection is not reported")
+		}
+	}
+}
+
+func TestListeners(t *testing.T) {
+	h1 := newTestHost(t, testConfig(t))
+	h2 := newTestHost(t, testConfig(t))
+
+	old := h1.Network().ListenAddresses()
+	require.Len(t, old, 1)
+	require.NoError(t, h2.Connect(context.Background(), peer.AddrInfo{ID: h1.ID(), Addrs: old}))
+
+	require.NoError(t, h1.AddListener("/ip4/127.0.0.1/tcp/0"))
+	listening := h1.Network().ListenAddresses()
+	require.Len(t, listening, 2)
+	var added ma.Multiaddr
+	for _, addr := range listening {
+		if !addr.Equal(old[0]) {
+			added = addr
+		}
+	}
+	require.NotNil(t, added)
+
+	require.NoError(t, h1.RemoveListener(old[0].String()))
+	require.Equal(t, []ma.Multiaddr{added}, h1.Network().ListenAddresses())
+	require.Error(t, h1.RemoveListener(old[0].String()))
+
+	// the connection accepted on the removed listener stays alive
+	require.Equal(t, network.Connected, h2.Network().Connectedness(h1.ID()))
+	require.Equal(t, network.Connected, h1.Network().Connectedness(h2.ID()))
+
+	h3 := newTestHost(t, testConfig(t))
+	require.Error(t, h3.Connect(context.Background(), peer.AddrInfo{ID: h1.ID(), Addrs: old}))
+	h4 := newTestHost(t, testConfig(t))
+	require.NoError(t, h4.Connect(context.Background(), peer.AddrInfo{ID: h1.ID(), Addrs: []ma.Multiaddr{added}}))
+}
+
+func TestForgetPeer(t *testing.T) {
+	h1 := newTestHost(t, testConfig(t))
+	h2 := newTestHost(t, testConfig(t))
+
+	require.NoError(t, h1.Connect(context.Background(), peer.AddrInfo{ID: h2.ID(), Addrs: h2.Addrs()}))
+	require.NoError(t, h1.Peerstore().Put(h2.ID(), "test", "value"))
+	require.NotEmpty(t, h1.Peerstore().Addrs(h2.ID()))
+
+	require.NoError(t, h1.ForgetPeer(h2.ID()))
+	require.Equal(t, network.NotConnected, h1.Network().Connectedness(h2.ID()))
+	require.Empty(t, h1.Network().ConnsToPeer(h2.ID()))
+	require.Empty(t, h1.Peerstore().Addrs(h2.ID()))
+	require.NotContains(t, h1.Peerstore().PeersWithAddrs(), h2.ID())
+	_, err := h1.Peerstore().Get(h2.ID(), "test")
+	require.Error(t, err)
+	require.NotContains(t, h1.GetPeers(), h2.ID())
+
+	// forgetting an unknown peer is a noop
+	require.NoError(t, h1.ForgetPeer(h2.ID()))
+}
+
+func TestConnections(t *testing.T) {
+	h1 := newTestHost(t, testConfig(t))
+	h2 := newTestHost(t, testConfig(t))
+	require.Empty(t, h1.Connections())
+
+	before := time.Now()
+	require.NoError(t, h1.Connect(context.Background(), peer.AddrInfo{ID: h2.ID(), Addrs: h2.Addrs()}))
+
+	conns := h1.Connections()
+	require.Len(t, conns, 1)
+	conn := conns[0]
+	require.NotEmpty(t, conn.ID)
+	require.Equal(t, h2.ID(), conn.Peer)
+	require.Equal(t, protocol.ID("/noise"), conn.Security)
+	require.Equal(t, protocol.ID("/yamux/1.0.0"), conn.Muxer)
+	require.Equal(t, network.DirOutbound, conn.Direction)
+	require.False(t, conn.Opened.Before(before.Add(-time.Second)))
+	require.Less(t, conn.Age(), time.Since(before)+time.Second)
+
+	require.Eventually(t, func() bool {
+		conns := h2.Connections()
+		return len(conns) == 1 && conns[0].Peer == h1.ID() && conns[0].Direction == network.DirInbound
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestProbe(t *testing.T) {
+	h1 := newTestHost(t, testConfig(t))
+	h2 := newTestHost(t, testConfig(t))
+
+	addr := h2.Network().ListenAddresses()[0]
+	require.NoError(t, h1.Probe(context.Background(), addr.String(), time.Second))
+	withID := addr.Encapsulate(ma.StringCast("/p2p/" + h2.ID().String()))
+	require.NoError(t, h1.Probe(context.Background(), withID.String(), time.Second))
+	require.Empty(t, h1.Peerstore().Addrs(h2.ID()))
+	require.Empty(t, h1.Network().ConnsToPeer(h2.ID()))
+
+	require.NoError(t, h2.RemoveListener(addr.String()))
+	require.Error(t, h1.Probe(context.Background(), addr.String(), time.Second))
+	require.Error(t, h1.Probe(context.Background(), "not an address", time.Second))
+}